@@ -6,6 +6,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	_ "time/tzdata" // alpine-образ без системной базы поясов
 
 	"github.com/Vasya-lis/firstWorkWithgRPC/services/api"
 )
@@ -0,0 +1,107 @@
+package apperrors
+
+// Kind класс ошибки, по нему транспорт выбирает код gRPC и HTTP статус
+type Kind int
+
+const (
+	KindInternal         Kind = iota // сбой сервиса, подробности клиенту не показываются
+	KindInvalid                      // неверный запрос клиента
+	KindNotFound                     // объект не найден
+	KindConflict                     // операция невозможна в текущем состоянии объекта
+	KindForbidden                    // нет прав на операцию
+	KindMethodNotAllowed             // метод HTTP не поддерживается
+)
+
+// CodeInternal код ошибок без собственного кода
+const CodeInternal = "INTERNAL"
+
+// AppError ошибка приложения со стабильным машиночитаемым кодом.
+// Текст берется из каталога сообщений, Params подставляются в шаблон.
+type AppError struct {
+	Code   string
+	Kind   Kind
+	Params map[string]string
+}
+
+func New(code string, kind Kind) *AppError {
+	return &AppError{Code: code, Kind: kind}
+}
+
+// Error возвращает текст на языке по умолчанию, он же уходит в логи
+func (e *AppError) Error() string {
+	return Message(e.Code, DefaultLang, e.Params)
+}
+
+// Is сравнивает ошибки по коду, поэтому копия с параметрами совпадает с исходной
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == e.Code
+}
+
+// With возвращает копию ошибки с параметром шаблона сообщения
+func (e *AppError) With(key, value string) *AppError {
+	params := make(map[string]string, len(e.Params)+1)
+	for k, v := range e.Params {
+		params[k] = v
+	}
+	params[key] = value
+	return &AppError{Code: e.Code, Kind: e.Kind, Params: params}
+}
+
+// Find ищет в цепочке ошибку приложения. Клиентские ошибки важнее внутренних,
+// чтобы обернутая ошибка вида "get task failed: task not found" получила код TASK_NOT_FOUND.
+func Find(err error) (*AppError, bool) {
+	var found *AppError
+	walk(err, func(e *AppError) bool {
+		if found == nil || found.Kind == KindInternal {
+			found = e
+		}
+		return e.Kind == KindInternal
+	})
+	return found, found != nil
+}
+
+// walk обходит дерево ошибок, пока visit возвращает true
+func walk(err error, visit func(*AppError) bool) bool {
+	if err == nil {
+		return true
+	}
+	if e, ok := err.(*AppError); ok && !visit(e) {
+		return false
+	}
+	switch u := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, inner := range u.Unwrap() {
+			if !walk(inner, visit) {
+				return false
+			}
+		}
+	case interface{ Unwrap() error }:
+		return walk(u.Unwrap(), visit)
+	}
+	return true
+}
+
+// Code возвращает стабильный код ошибки, для неизвестных — INTERNAL
+func Code(err error) string {
+	if e, ok := Find(err); ok {
+		return e.Code
+	}
+	return CodeInternal
+}
+
+// KindOf возвращает класс ошибки, для неизвестных — KindInternal
+func KindOf(err error) Kind {
+	if e, ok := Find(err); ok {
+		return e.Kind
+	}
+	return KindInternal
+}
+
+// ParamsOf возвращает параметры сообщения найденной ошибки
+func ParamsOf(err error) map[string]string {
+	if e, ok := Find(err); ok {
+		return e.Params
+	}
+	return nil
+}
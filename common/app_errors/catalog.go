@@ -0,0 +1,254 @@
+package apperrors
+
+import "strings"
+
+// поддерживаемые языки сообщений
+const (
+	LangEN = "en"
+	LangRU = "ru"
+)
+
+// DefaultLang язык логов, статусов gRPC и ответов без Accept-Language
+const DefaultLang = LangEN
+
+// catalog тексты ошибок по языкам и кодам, {name} — параметр из AppError.Params.
+// Коды транспорта (UNAVAILABLE, DEADLINE_EXCEEDED...) нужны для ошибок без кода приложения.
+var catalog = map[string]map[string]string{
+	LangEN: {
+		"TASK_NOT_FOUND":      "task not found",
+		"INVALID_TASK_ID":     "invalid task id",
+		"DATE_REQUIRED":       "date is required",
+		"TITLE_REQUIRED":      "title is required",
+		"TASK_REQUIRED":       "task is required",
+		"INVALID_DATE_FORMAT": "invalid date format",
+		"INVALID_REPEAT":      "invalid repeat rule",
+		"INVALID_TIME_ZONE":   "invalid time zone",
+		"INVALID_CATCH_UP":    "invalid catch-up policy",
+		"INVALID_PRIORITY":    "invalid priority: expected low, normal, high or urgent",
+		"INVALID_ORDER":       "invalid order: expected date or priority",
+		"TAG_NOT_FOUND":       "tag not found",
+		"TAG_EXISTS":          "tag {name} already exists, merge the tags instead",
+		"PROJECT_NOT_FOUND":   "project not found",
+		"PROJECT_ARCHIVED":    "project is archived",
+		"NOT_REPEATING":       "task is not repeating",
+		"SNOOZE_REQUIRED":     "snooze days or date is required",
+		"INVALID_JSON":        "invalid JSON",
+		"INVALID_PARAMETER":   "invalid query parameter {name}",
+		"ADMIN_DISABLED":      "admin methods are disabled",
+		"INVALID_ADMIN_TOKEN": "invalid admin token",
+		"METHOD_NOT_ALLOWED":  "method not allowed",
+		"INTERNAL":            "internal server error",
+
+		"PARENT_NOT_FOUND":         "parent task not found",
+		"SUBTASK_CYCLE":            "a task cannot be a subtask of itself or of its subtasks",
+		"SUBTASK_TOO_DEEP":         "subtasks are nested too deep: max {max} levels",
+		"CHECKLIST_ITEM_NOT_FOUND": "checklist item not found",
+
+		"TASK_BLOCKED":             "task is blocked by unfinished tasks: {ids}",
+		"DEPENDENCY_CYCLE":         "dependency would create a cycle",
+		"DEPENDENCY_NOT_FOUND":     "dependency not found",
+		"GET_DEPENDENCIES_FAILED":  "get dependencies failed",
+		"ADD_DEPENDENCY_FAILED":    "add dependency failed",
+		"REMOVE_DEPENDENCY_FAILED": "remove dependency failed",
+
+		"INVALID_STATUS":            "unknown status",
+		"STATUS_TRANSITION":         "cannot change status from {from} to {to}",
+		"SET_STATUS_FAILED":         "set status failed",
+		"GET_STATUS_CHANGES_FAILED": "get status history failed",
+
+		"REVISION_NOT_FOUND":   "task revision not found",
+		"ADD_REVISION_FAILED":  "save task revision failed",
+		"GET_REVISIONS_FAILED": "get task revisions failed",
+
+		"ADD_AUDIT_EVENT_FAILED":  "write audit event failed",
+		"GET_AUDIT_EVENTS_FAILED": "get audit events failed",
+
+		"ATTACHMENT_NOT_FOUND":     "attachment not found",
+		"ATTACHMENT_REQUIRED":      "file is required",
+		"ATTACHMENT_TOO_LARGE":     "file is too large: max {max} bytes",
+		"ADD_ATTACHMENT_FAILED":    "add attachment failed",
+		"GET_ATTACHMENTS_FAILED":   "get attachments failed",
+		"DELETE_ATTACHMENT_FAILED": "delete attachment failed",
+		"BLOB_STORE_FAILED":        "attachment storage failed",
+
+		"INVALID_FIELDS": "invalid fields: {fields}",
+		"FIELD_REQUIRED": "value is required",
+		"FIELD_TOO_LONG": "value is too long: max {max} characters",
+		"FIELD_PATTERN":  "value does not match pattern {pattern}",
+
+		"GET_TASK_CACHE_FAILED":    "get task cache failed",
+		"SET_TASK_CACHE_FAILED":    "set task cache failed",
+		"GET_TASKS_CACHE_FAILED":   "get tasks cache failed",
+		"SET_TASKS_CACHE_FAILED":   "set tasks cache failed",
+		"DELETE_TASK_CACHE_FAILED": "delete task cache failed",
+
+		"ADD_TASK_FAILED":         "add task failed",
+		"GET_TASKS_FAILED":        "get tasks failed",
+		"GET_TASK_FAILED":         "get task failed",
+		"UPDATE_TASK_FAILED":      "update task failed",
+		"DELETE_TASK_FAILED":      "delete task failed",
+		"UPDATE_TASK_DATE_FAILED": "update task date failed",
+		"SNOOZE_TASK_FAILED":      "snooze task failed",
+		"SKIP_OCCURRENCE_FAILED":  "skip occurrence failed",
+		"ADD_MISSED_FAILED":       "add missed occurrences failed",
+		"GET_MISSED_FAILED":       "get missed occurrences failed",
+		"CATCH_UP_FAILED":         "catch up missed occurrences failed",
+		"GET_TAGS_FAILED":         "get tags failed",
+		"SET_TASK_TAGS_FAILED":    "set task tags failed",
+		"ADD_TAG_FAILED":          "add tag failed",
+		"UPDATE_TAG_FAILED":       "update tag failed",
+		"DELETE_TAG_FAILED":       "delete tag failed",
+		"MERGE_TAGS_FAILED":       "merge tags failed",
+		"GET_PROJECTS_FAILED":     "get projects failed",
+		"ADD_PROJECT_FAILED":      "add project failed",
+		"UPDATE_PROJECT_FAILED":   "update project failed",
+		"DELETE_PROJECT_FAILED":   "delete project failed",
+		"GET_SUBTASKS_FAILED":     "get subtasks failed",
+		"UPDATE_CHECKLIST_FAILED": "update checklist failed",
+
+		"INVALID_ARGUMENT":    "invalid request",
+		"NOT_FOUND":           "not found",
+		"FAILED_PRECONDITION": "operation is not allowed in the current state",
+		"PERMISSION_DENIED":   "permission denied",
+		"UNAVAILABLE":         "service is temporarily unavailable",
+		"DEADLINE_EXCEEDED":   "request timed out",
+		"CANCELED":            "request was canceled",
+	},
+	LangRU: {
+		"TASK_NOT_FOUND":      "задача не найдена",
+		"INVALID_TASK_ID":     "неверный идентификатор задачи",
+		"DATE_REQUIRED":       "не указана дата",
+		"TITLE_REQUIRED":      "не указан заголовок задачи",
+		"TASK_REQUIRED":       "не передана задача",
+		"INVALID_DATE_FORMAT": "неверный формат даты",
+		"INVALID_REPEAT":      "неверное правило повторения",
+		"INVALID_TIME_ZONE":   "неверный часовой пояс",
+		"INVALID_CATCH_UP":    "неверная политика пропущенных повторений",
+		"INVALID_PRIORITY":    "неверный приоритет: ожидается low, normal, high или urgent",
+		"INVALID_ORDER":       "неверный порядок: ожидается date или priority",
+		"TAG_NOT_FOUND":       "метка не найдена",
+		"TAG_EXISTS":          "метка {name} уже существует, объедините метки",
+		"PROJECT_NOT_FOUND":   "проект не найден",
+		"PROJECT_ARCHIVED":    "проект в архиве",
+		"NOT_REPEATING":       "задача не повторяется",
+		"SNOOZE_REQUIRED":     "укажите число дней или дату, на которую отложить",
+		"INVALID_JSON":        "ошибка десериализации JSON",
+		"INVALID_PARAMETER":   "неверный параметр запроса {name}",
+		"ADMIN_DISABLED":      "админские методы отключены",
+		"INVALID_ADMIN_TOKEN": "неверный токен администратора",
+		"METHOD_NOT_ALLOWED":  "метод не поддерживается",
+		"INTERNAL":            "внутренняя ошибка сервера",
+
+		"PARENT_NOT_FOUND":         "родительская задача не найдена",
+		"SUBTASK_CYCLE":            "задача не может быть подзадачей самой себя или своих подзадач",
+		"SUBTASK_TOO_DEEP":         "слишком глубокая вложенность подзадач: не более {max} уровней",
+		"CHECKLIST_ITEM_NOT_FOUND": "пункт чек-листа не найден",
+
+		"TASK_BLOCKED":             "задача ждет невыполненных задач: {ids}",
+		"DEPENDENCY_CYCLE":         "зависимость образует цикл",
+		"DEPENDENCY_NOT_FOUND":     "зависимость не найдена",
+		"GET_DEPENDENCIES_FAILED":  "не удалось получить зависимости",
+		"ADD_DEPENDENCY_FAILED":    "не удалось добавить зависимость",
+		"REMOVE_DEPENDENCY_FAILED": "не удалось удалить зависимость",
+
+		"INVALID_STATUS":            "неизвестный статус",
+		"STATUS_TRANSITION":         "нельзя сменить статус {from} на {to}",
+		"SET_STATUS_FAILED":         "не удалось сменить статус",
+		"GET_STATUS_CHANGES_FAILED": "не удалось получить историю статусов",
+
+		"REVISION_NOT_FOUND":   "версия задачи не найдена",
+		"ADD_REVISION_FAILED":  "не удалось сохранить версию задачи",
+		"GET_REVISIONS_FAILED": "не удалось получить версии задачи",
+
+		"ADD_AUDIT_EVENT_FAILED":  "не удалось записать событие аудита",
+		"GET_AUDIT_EVENTS_FAILED": "не удалось получить журнал аудита",
+
+		"ATTACHMENT_NOT_FOUND":     "вложение не найдено",
+		"ATTACHMENT_REQUIRED":      "нужен файл",
+		"ATTACHMENT_TOO_LARGE":     "слишком большой файл: не более {max} байт",
+		"ADD_ATTACHMENT_FAILED":    "не удалось добавить вложение",
+		"GET_ATTACHMENTS_FAILED":   "не удалось получить вложения",
+		"DELETE_ATTACHMENT_FAILED": "не удалось удалить вложение",
+		"BLOB_STORE_FAILED":        "ошибка хранилища вложений",
+
+		"INVALID_FIELDS": "неверные поля: {fields}",
+		"FIELD_REQUIRED": "обязательное поле",
+		"FIELD_TOO_LONG": "слишком длинное значение: не более {max} символов",
+		"FIELD_PATTERN":  "значение не соответствует шаблону {pattern}",
+
+		"GET_TASK_CACHE_FAILED":    "не удалось прочитать задачу из кэша",
+		"SET_TASK_CACHE_FAILED":    "не удалось сохранить задачу в кэш",
+		"GET_TASKS_CACHE_FAILED":   "не удалось прочитать задачи из кэша",
+		"SET_TASKS_CACHE_FAILED":   "не удалось сохранить задачи в кэш",
+		"DELETE_TASK_CACHE_FAILED": "не удалось удалить задачу из кэша",
+
+		"ADD_TASK_FAILED":         "не удалось добавить задачу",
+		"GET_TASKS_FAILED":        "не удалось получить задачи",
+		"GET_TASK_FAILED":         "не удалось получить задачу",
+		"UPDATE_TASK_FAILED":      "не удалось обновить задачу",
+		"DELETE_TASK_FAILED":      "не удалось удалить задачу",
+		"UPDATE_TASK_DATE_FAILED": "не удалось перенести задачу",
+		"SNOOZE_TASK_FAILED":      "не удалось отложить задачу",
+		"SKIP_OCCURRENCE_FAILED":  "не удалось пропустить повторение",
+		"ADD_MISSED_FAILED":       "не удалось сохранить пропущенные повторения",
+		"GET_MISSED_FAILED":       "не удалось получить пропущенные повторения",
+		"CATCH_UP_FAILED":         "не удалось обработать пропущенные повторения",
+		"GET_TAGS_FAILED":         "не удалось получить метки",
+		"SET_TASK_TAGS_FAILED":    "не удалось сохранить метки задачи",
+		"ADD_TAG_FAILED":          "не удалось создать метку",
+		"UPDATE_TAG_FAILED":       "не удалось переименовать метку",
+		"DELETE_TAG_FAILED":       "не удалось удалить метку",
+		"MERGE_TAGS_FAILED":       "не удалось объединить метки",
+		"GET_PROJECTS_FAILED":     "не удалось получить проекты",
+		"ADD_PROJECT_FAILED":      "не удалось создать проект",
+		"UPDATE_PROJECT_FAILED":   "не удалось обновить проект",
+		"DELETE_PROJECT_FAILED":   "не удалось удалить проект",
+		"GET_SUBTASKS_FAILED":     "не удалось получить подзадачи",
+		"UPDATE_CHECKLIST_FAILED": "не удалось обновить чек-лист",
+
+		"INVALID_ARGUMENT":    "неверный запрос",
+		"NOT_FOUND":           "не найдено",
+		"FAILED_PRECONDITION": "операция недоступна в текущем состоянии",
+		"PERMISSION_DENIED":   "доступ запрещен",
+		"UNAVAILABLE":         "сервис временно недоступен",
+		"DEADLINE_EXCEEDED":   "превышено время ожидания",
+		"CANCELED":            "запрос отменен",
+	},
+}
+
+// Message возвращает текст ошибки по коду на языке lang, при отсутствии
+// перевода — на языке по умолчанию, для неизвестного кода — сам код
+func Message(code, lang string, params map[string]string) string {
+	msg, ok := catalog[lang][code]
+	if !ok {
+		msg, ok = catalog[DefaultLang][code]
+	}
+	if !ok {
+		return code
+	}
+	for k, v := range params {
+		msg = strings.ReplaceAll(msg, "{"+k+"}", v)
+	}
+	return msg
+}
+
+// HasMessage проверяет, есть ли код в каталоге
+func HasMessage(code string) bool {
+	_, ok := catalog[DefaultLang][code]
+	return ok
+}
+
+// Localize возвращает текст ошибки приложения на языке lang
+func Localize(err error, lang string) string {
+	e, ok := Find(err)
+	if !ok {
+		return Message(CodeInternal, lang, nil)
+	}
+	return Message(e.Code, lang, e.Params)
+}
+
+// Supported проверяет, есть ли каталог для языка
+func Supported(lang string) bool {
+	_, ok := catalog[lang]
+	return ok
+}
@@ -1,30 +1,105 @@
 package apperrors
 
-import (
-	"errors"
-)
-
-// базовые ошибки
+// базовые ошибки, код — часть контракта API и не меняется при правке текста
 var (
-	ErrTaskNotFound      = errors.New("task not found")
-	ErrInvalidTaskID     = errors.New("invalid task id")
-	ErrDateRequired      = errors.New("date is required")
-	ErrTitleRequired     = errors.New("title is required")
-	ErrTaskRequired      = errors.New("task is required")
-	ErrInvalidDateFormat = errors.New("invalid date format")
+	ErrTaskNotFound      = New("TASK_NOT_FOUND", KindNotFound)
+	ErrInvalidTaskID     = New("INVALID_TASK_ID", KindInvalid)
+	ErrDateRequired      = New("DATE_REQUIRED", KindInvalid)
+	ErrTitleRequired     = New("TITLE_REQUIRED", KindInvalid)
+	ErrTaskRequired      = New("TASK_REQUIRED", KindInvalid)
+	ErrInvalidDateFormat = New("INVALID_DATE_FORMAT", KindInvalid)
+	ErrInvalidRepeat     = New("INVALID_REPEAT", KindInvalid)
+	ErrInvalidTimeZone   = New("INVALID_TIME_ZONE", KindInvalid)
+	ErrInvalidCatchUp    = New("INVALID_CATCH_UP", KindInvalid)
+	ErrInvalidPriority   = New("INVALID_PRIORITY", KindInvalid)
+	ErrInvalidOrder      = New("INVALID_ORDER", KindInvalid)
+	ErrTagNotFound       = New("TAG_NOT_FOUND", KindNotFound)
+	ErrTagExists         = New("TAG_EXISTS", KindConflict) // параметр name
+	ErrProjectNotFound   = New("PROJECT_NOT_FOUND", KindNotFound)
+	ErrProjectArchived   = New("PROJECT_ARCHIVED", KindConflict)
+	ErrNotRepeating      = New("NOT_REPEATING", KindConflict)
+	ErrSnoozeRequired    = New("SNOOZE_REQUIRED", KindInvalid)
+	ErrInvalidJSON       = New("INVALID_JSON", KindInvalid)
+	ErrInvalidParameter  = New("INVALID_PARAMETER", KindInvalid) // параметр name
+	ErrAdminDisabled     = New("ADMIN_DISABLED", KindForbidden)
+	ErrAdminToken        = New("INVALID_ADMIN_TOKEN", KindForbidden)
+	ErrMethodNotAllowed  = New("METHOD_NOT_ALLOWED", KindMethodNotAllowed)
+	ErrInternal          = New(CodeInternal, KindInternal)
+
+	// ошибки подзадач и чек-листов
+	ErrParentNotFound = New("PARENT_NOT_FOUND", KindInvalid)
+	ErrSubtaskCycle   = New("SUBTASK_CYCLE", KindInvalid)
+	ErrSubtaskTooDeep = New("SUBTASK_TOO_DEEP", KindInvalid) // параметр max
+	ErrChecklistItem  = New("CHECKLIST_ITEM_NOT_FOUND", KindNotFound)
+
+	// ошибки зависимостей
+	ErrTaskBlocked        = New("TASK_BLOCKED", KindConflict) // параметр ids
+	ErrDependencyCycle    = New("DEPENDENCY_CYCLE", KindInvalid)
+	ErrDependencyNotFound = New("DEPENDENCY_NOT_FOUND", KindNotFound)
+	ErrGetDependencies    = New("GET_DEPENDENCIES_FAILED", KindInternal)
+	ErrAddDependency      = New("ADD_DEPENDENCY_FAILED", KindInternal)
+	ErrRemoveDependency   = New("REMOVE_DEPENDENCY_FAILED", KindInternal)
+
+	// ошибки статусов
+	ErrInvalidStatus    = New("INVALID_STATUS", KindInvalid)
+	ErrStatusTransition = New("STATUS_TRANSITION", KindConflict) // параметры from, to
+	ErrSetStatus        = New("SET_STATUS_FAILED", KindInternal)
+	ErrGetStatusChanges = New("GET_STATUS_CHANGES_FAILED", KindInternal)
+
+	// ошибки версий задачи
+	ErrRevisionNotFound = New("REVISION_NOT_FOUND", KindNotFound)
+	ErrAddRevision      = New("ADD_REVISION_FAILED", KindInternal)
+	ErrGetRevisions     = New("GET_REVISIONS_FAILED", KindInternal)
+
+	// ошибки журнала аудита
+	ErrAddAuditEvent  = New("ADD_AUDIT_EVENT_FAILED", KindInternal)
+	ErrGetAuditEvents = New("GET_AUDIT_EVENTS_FAILED", KindInternal)
+
+	// ошибки вложений
+	ErrAttachmentNotFound = New("ATTACHMENT_NOT_FOUND", KindNotFound)
+	ErrAttachmentRequired = New("ATTACHMENT_REQUIRED", KindInvalid)
+	ErrAttachmentTooLarge = New("ATTACHMENT_TOO_LARGE", KindInvalid) // параметр max
+	ErrAddAttachment      = New("ADD_ATTACHMENT_FAILED", KindInternal)
+	ErrGetAttachments     = New("GET_ATTACHMENTS_FAILED", KindInternal)
+	ErrDeleteAttachment   = New("DELETE_ATTACHMENT_FAILED", KindInternal)
+	ErrBlobStore          = New("BLOB_STORE_FAILED", KindInternal)
+
+	// ошибки ограничений полей из proto
+	ErrInvalidFields = New("INVALID_FIELDS", KindInvalid) // параметр fields
+	ErrFieldRequired = New("FIELD_REQUIRED", KindInvalid)
+	ErrFieldTooLong  = New("FIELD_TOO_LONG", KindInvalid) // параметр max
+	ErrFieldPattern  = New("FIELD_PATTERN", KindInvalid)  // параметр pattern
 
 	// кэш ошибки
-	ErrGetTaskCache    = errors.New("get task cache failed")
-	ErrSetTaskCache    = errors.New("set task cache failed")
-	ErrGetTasksCache   = errors.New("get tasks cache failed")
-	ErrSetTasksCache   = errors.New("set tasks cache failed")
-	ErrDeleteTaskCache = errors.New("delete task cache failed")
+	ErrGetTaskCache    = New("GET_TASK_CACHE_FAILED", KindInternal)
+	ErrSetTaskCache    = New("SET_TASK_CACHE_FAILED", KindInternal)
+	ErrGetTasksCache   = New("GET_TASKS_CACHE_FAILED", KindInternal)
+	ErrSetTasksCache   = New("SET_TASKS_CACHE_FAILED", KindInternal)
+	ErrDeleteTaskCache = New("DELETE_TASK_CACHE_FAILED", KindInternal)
 
 	// репо ошибки
-	ErrAddTask        = errors.New("add task failed")
-	ErrGetTasks       = errors.New("get tasks failed")
-	ErrGetTask        = errors.New("get task failed")
-	ErrUpdateTask     = errors.New("update task failed")
-	ErrDeleteTask     = errors.New("delete task failed")
-	ErrUpdateTaskDate = errors.New("update task date failed")
+	ErrAddTask        = New("ADD_TASK_FAILED", KindInternal)
+	ErrGetTasks       = New("GET_TASKS_FAILED", KindInternal)
+	ErrGetTask        = New("GET_TASK_FAILED", KindInternal)
+	ErrUpdateTask     = New("UPDATE_TASK_FAILED", KindInternal)
+	ErrDeleteTask     = New("DELETE_TASK_FAILED", KindInternal)
+	ErrUpdateTaskDate = New("UPDATE_TASK_DATE_FAILED", KindInternal)
+	ErrSnoozeTask     = New("SNOOZE_TASK_FAILED", KindInternal)
+	ErrSkipOccurrence = New("SKIP_OCCURRENCE_FAILED", KindInternal)
+	ErrAddMissed      = New("ADD_MISSED_FAILED", KindInternal)
+	ErrGetMissed      = New("GET_MISSED_FAILED", KindInternal)
+	ErrCatchUp        = New("CATCH_UP_FAILED", KindInternal)
+	ErrGetTags        = New("GET_TAGS_FAILED", KindInternal)
+	ErrSetTaskTags    = New("SET_TASK_TAGS_FAILED", KindInternal)
+	ErrAddTag         = New("ADD_TAG_FAILED", KindInternal)
+	ErrUpdateTag      = New("UPDATE_TAG_FAILED", KindInternal)
+	ErrDeleteTag      = New("DELETE_TAG_FAILED", KindInternal)
+	ErrMergeTags      = New("MERGE_TAGS_FAILED", KindInternal)
+	ErrGetProjects    = New("GET_PROJECTS_FAILED", KindInternal)
+	ErrAddProject     = New("ADD_PROJECT_FAILED", KindInternal)
+	ErrUpdateProject  = New("UPDATE_PROJECT_FAILED", KindInternal)
+	ErrDeleteProject  = New("DELETE_PROJECT_FAILED", KindInternal)
+
+	ErrGetSubtasks     = New("GET_SUBTASKS_FAILED", KindInternal)
+	ErrUpdateChecklist = New("UPDATE_CHECKLIST_FAILED", KindInternal)
 )
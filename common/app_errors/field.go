@@ -0,0 +1,36 @@
+package apperrors
+
+// FieldError ошибка проверки конкретного поля задачи
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func NewFieldError(field string, err error) *FieldError {
+	return &FieldError{Field: field, Err: err}
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldErrors собирает ошибки полей из цепочки, в том числе объединенные через errors.Join
+func FieldErrors(err error) []*FieldError {
+	switch e := err.(type) {
+	case *FieldError:
+		return []*FieldError{e}
+	case interface{ Unwrap() []error }:
+		var result []*FieldError
+		for _, inner := range e.Unwrap() {
+			result = append(result, FieldErrors(inner)...)
+		}
+		return result
+	case interface{ Unwrap() error }:
+		return FieldErrors(e.Unwrap())
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+// Package blob хранилища содержимого вложений: каталог на диске и S3-совместимое
+// объектное хранилище (AWS S3, MinIO). Объект адресуется ключом вида "a/b/c".
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrNotFound объекта с ключом нет в хранилище
+var ErrNotFound = errors.New("blob not found")
+
+// Store хранилище содержимого вложений
+type Store interface {
+	// Put сохраняет size байт из r под ключом key, существующий объект заменяется
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+	// Get открывает объект на чтение, ErrNotFound — объекта нет
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete удаляет объект, отсутствующий объект ошибкой не считается
+	Delete(ctx context.Context, key string) error
+}
+
+// checkKey ключ — относительный путь из непустых частей без . и ..,
+// поэтому он не выходит за каталог хранилища и одинаково читается в S3
+func checkKey(key string) error {
+	if key == "" || strings.ContainsAny(key, "\\\x00") {
+		return fmt.Errorf("invalid blob key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid blob key %q", key)
+		}
+	}
+	return nil
+}
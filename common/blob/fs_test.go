@@ -0,0 +1,74 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFSStore(t *testing.T) {
+	root := t.TempDir()
+	s, err := NewFSStore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	key := "tasks/1/report.pdf"
+	data := []byte("report")
+	if err := s.Put(ctx, key, bytes.NewReader(data), int64(len(data))); err != nil {
+		t.Fatalf("Put() error: %v", err)
+	}
+	// существующий объект заменяется
+	data = []byte("report v2")
+	if err := s.Put(ctx, key, bytes.NewReader(data), int64(len(data))); err != nil {
+		t.Fatalf("Put() replace error: %v", err)
+	}
+
+	r, err := s.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	got, err := io.ReadAll(r)
+	r.Close()
+	if err != nil || !bytes.Equal(got, data) {
+		t.Errorf("Get() = %q, %v, want %q", got, err, data)
+	}
+
+	// размер не совпал: объект не заменяется, временный файл не остается
+	if err := s.Put(ctx, key, strings.NewReader("short"), 100); err == nil {
+		t.Error("Put() with wrong size error = nil")
+	}
+	entries, err := os.ReadDir(filepath.Join(root, "tasks", "1"))
+	if err != nil || len(entries) != 1 {
+		t.Errorf("blob dir entries = %v, %v, want only the object", entries, err)
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() error: %v", err)
+	}
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete error = %v, want ErrNotFound", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Errorf("second Delete() error: %v", err)
+	}
+}
+
+func TestCheckKey(t *testing.T) {
+	for _, key := range []string{"a", "tasks/1/a b.txt", "отчет.pdf"} {
+		if err := checkKey(key); err != nil {
+			t.Errorf("checkKey(%q) error: %v", key, err)
+		}
+	}
+	for _, key := range []string{"", "/a", "a/", "a//b", "../a", "a/./b", `a\b`, "a\x00b"} {
+		if err := checkKey(key); err == nil {
+			t.Errorf("checkKey(%q) error = nil", key)
+		}
+	}
+}
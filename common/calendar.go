@@ -0,0 +1,190 @@
+package common
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Calendar производственный календарь: праздники и перенесенные рабочие выходные
+type Calendar struct {
+	holidays map[string]bool // нерабочие дни помимо выходных
+	workdays map[string]bool // рабочие субботы и воскресенья
+}
+
+// calendarFile формат JSON-календаря, даты в формате YYYYMMDD
+type calendarFile struct {
+	Holidays []string `json:"holidays"`
+	Workdays []string `json:"workdays"`
+}
+
+// календарь по умолчанию: рабочие дни с понедельника по пятницу
+var calendar = &Calendar{}
+
+// InitCalendar загружает календарь из файла, пустой путь — только выходные
+func InitCalendar(path string) error {
+	if path == "" {
+		calendar = &Calendar{}
+		return nil
+	}
+	c, err := LoadCalendar(path)
+	if err != nil {
+		return err
+	}
+	calendar = c
+	return nil
+}
+
+// LoadCalendar читает календарь из .json или .ics файла
+func LoadCalendar(path string) (*Calendar, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open calendar: %w", err)
+	}
+	defer f.Close()
+
+	c := &Calendar{
+		holidays: make(map[string]bool),
+		workdays: make(map[string]bool),
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		var data calendarFile
+		if err := json.NewDecoder(f).Decode(&data); err != nil {
+			return nil, fmt.Errorf("failed to decode calendar: %w", err)
+		}
+		for _, d := range data.Holidays {
+			if _, err := time.Parse(FormDate, d); err != nil {
+				return nil, fmt.Errorf("invalid holiday %q: %w", d, err)
+			}
+			c.holidays[d] = true
+		}
+		for _, d := range data.Workdays {
+			if _, err := time.Parse(FormDate, d); err != nil {
+				return nil, fmt.Errorf("invalid workday %q: %w", d, err)
+			}
+			c.workdays[d] = true
+		}
+	case ".ics":
+		if err := c.readICS(f); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("unsupported calendar format, expected .json or .ics")
+	}
+
+	return c, nil
+}
+
+// readICS считает каждое событие VEVENT нерабочим днем (DTEND не включается)
+func (c *Calendar) readICS(f *os.File) error {
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		// перенос длинных строк по RFC 5545
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read calendar: %w", err)
+	}
+
+	var start, end time.Time
+	inEvent := false
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		name, _, _ = strings.Cut(name, ";")
+		switch strings.ToUpper(name) {
+		case "BEGIN":
+			if value == "VEVENT" {
+				inEvent = true
+				start, end = time.Time{}, time.Time{}
+			}
+		case "DTSTART", "DTEND":
+			if !inEvent || len(value) < 8 {
+				continue
+			}
+			d, err := time.Parse(FormDate, value[:8])
+			if err != nil {
+				return fmt.Errorf("invalid calendar date %q: %w", value, err)
+			}
+			if strings.EqualFold(name, "DTSTART") {
+				start = d
+			} else {
+				end = d
+			}
+		case "END":
+			if value != "VEVENT" || !inEvent {
+				continue
+			}
+			inEvent = false
+			if start.IsZero() {
+				continue
+			}
+			if end.IsZero() || !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+				c.holidays[d.Format(FormDate)] = true
+			}
+		}
+	}
+	return nil
+}
+
+// IsWorkday проверяет, рабочий ли день по календарю
+func (c *Calendar) IsWorkday(t time.Time) bool {
+	key := t.Format(FormDate)
+	if c.workdays[key] {
+		return true
+	}
+	if c.holidays[key] {
+		return false
+	}
+	return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+}
+
+// shiftToWorkday переносит дату на ближайший рабочий день по политике правила.
+// Если перенос назад попадает на сегодня или раньше, берется следующая дата серии.
+func shiftToWorkday(rule *RepeatRule, date, now time.Time) (time.Time, error) {
+	step := 1
+	if rule.Shift == ShiftPrevWorkday {
+		step = -1
+	}
+	limit := searchLimit(date, now)
+	// при переносе назад дни от floor до прошлой даты серии уже проверены, рабочих среди них нет
+	floor := now
+	for !date.After(limit) {
+		shifted := date
+		for !calendar.IsWorkday(shifted) && shifted.After(floor) {
+			shifted = shifted.AddDate(0, 0, step)
+			// в календаре не осталось рабочих дней
+			if shifted.After(limit) {
+				return time.Time{}, errNoOccurrence
+			}
+		}
+		if shifted.After(floor) && AfterNow(shifted, now) {
+			return shifted, nil
+		}
+		floor = date
+		next, err := nextByRule(rule, date, now)
+		if err != nil {
+			return time.Time{}, err
+		}
+		date = next
+	}
+	return time.Time{}, errNoOccurrence
+}
@@ -0,0 +1,129 @@
+package common
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeCalendar(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// workdaysOf рабочие дни календаря в диапазоне дат включительно
+func workdaysOf(t *testing.T, c *Calendar, from, to string) map[string]bool {
+	t.Helper()
+	days := make(map[string]bool)
+	for d := mustDate(t, from); !d.After(mustDate(t, to)); d = d.AddDate(0, 0, 1) {
+		days[d.Format(FormDate)] = c.IsWorkday(d)
+	}
+	return days
+}
+
+func TestLoadCalendarJSON(t *testing.T) {
+	path := writeCalendar(t, "calendar.json", `{
+		"holidays": ["20240101", "20240102", "20240308"],
+		"workdays": ["20240427"]
+	}`)
+	c, err := LoadCalendar(path)
+	if err != nil {
+		t.Fatalf("LoadCalendar() error: %v", err)
+	}
+
+	cases := map[string]bool{
+		"20240101": false, // праздник в понедельник
+		"20240102": false,
+		"20240103": true,
+		"20240106": false, // обычная суббота
+		"20240308": false,
+		"20240427": true, // рабочая суббота
+		"20240428": false,
+	}
+	for date, want := range cases {
+		if got := c.IsWorkday(mustDate(t, date)); got != want {
+			t.Errorf("IsWorkday(%s) = %v, want %v", date, got, want)
+		}
+	}
+}
+
+func TestLoadCalendarICS(t *testing.T) {
+	path := writeCalendar(t, "calendar.ics", "BEGIN:VCALENDAR\r\n"+
+		"VERSION:2.0\r\n"+
+		// многодневное событие, DTEND не включается
+		"BEGIN:VEVENT\r\n"+
+		"DTSTART;VALUE=DATE:20240101\r\n"+
+		"DTEND;VALUE=DATE:20240104\r\n"+
+		"SUMMARY:New year\r\n"+
+		"END:VEVENT\r\n"+
+		// событие без DTEND — один день, длинная строка перенесена
+		"BEGIN:VEVENT\r\n"+
+		"DTSTART:20240308T000000Z\r\n"+
+		"SUMMARY:International Women's\r\n"+
+		"  Day\r\n"+
+		"END:VEVENT\r\n"+
+		// дата вне события не учитывается
+		"DTSTART:20240501\r\n"+
+		"END:VCALENDAR\r\n")
+	c, err := LoadCalendar(path)
+	if err != nil {
+		t.Fatalf("LoadCalendar() error: %v", err)
+	}
+
+	got := workdaysOf(t, c, "20240101", "20240105")
+	want := map[string]bool{
+		"20240101": false, "20240102": false, "20240103": false, "20240104": true, "20240105": true,
+	}
+	for date, w := range want {
+		if got[date] != w {
+			t.Errorf("IsWorkday(%s) = %v, want %v", date, got[date], w)
+		}
+	}
+	if c.IsWorkday(mustDate(t, "20240308")) {
+		t.Error("IsWorkday(20240308) = true, want false")
+	}
+	if !c.IsWorkday(mustDate(t, "20240501")) {
+		t.Error("IsWorkday(20240501) = false, want true")
+	}
+}
+
+func TestLoadCalendarErrors(t *testing.T) {
+	cases := []struct {
+		name, content string
+	}{
+		{"broken.json", `{"holidays": [`},
+		{"bad-holiday.json", `{"holidays": ["2024-01-01"]}`},
+		{"bad-workday.json", `{"workdays": ["20241301"]}`},
+		{"bad-date.ics", "BEGIN:VEVENT\nDTSTART:20241340\nEND:VEVENT\n"},
+		{"calendar.txt", "20240101\n"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := LoadCalendar(writeCalendar(t, tc.name, tc.content)); err == nil {
+				t.Error("LoadCalendar() = nil error, want error")
+			}
+		})
+	}
+
+	if _, err := LoadCalendar(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadCalendar() for missing file: want error")
+	}
+}
+
+func TestDefaultCalendar(t *testing.T) {
+	if err := InitCalendar(""); err != nil {
+		t.Fatal(err)
+	}
+	got := workdaysOf(t, calendar, "20240101", "20240107")
+	for date, w := range map[string]bool{
+		"20240101": true, "20240105": true, "20240106": false, "20240107": false,
+	} {
+		if got[date] != w {
+			t.Errorf("IsWorkday(%s) = %v, want %v", date, got[date], w)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package common
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Clock источник текущего времени, внедряется в сервисы вместо прямых вызовов time.Now
+type Clock interface {
+	Now() time.Time
+}
+
+// FrozenClock системные часы, которые можно заморозить на заданном моменте (режим стенда)
+type FrozenClock struct {
+	mu     sync.RWMutex
+	frozen time.Time // нулевое — часы идут
+}
+
+// NewClock создает часы, frozen — момент заморозки в формате YYYYMMDD или RFC 3339,
+// пустая строка — системное время
+func NewClock(frozen string) (*FrozenClock, error) {
+	c := &FrozenClock{}
+	if frozen == "" {
+		return c, nil
+	}
+	t, err := ParseClockTime(frozen)
+	if err != nil {
+		return nil, err
+	}
+	c.Freeze(t)
+	return c, nil
+}
+
+func (c *FrozenClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.frozen.IsZero() {
+		return time.Now()
+	}
+	return c.frozen
+}
+
+// Freeze останавливает часы на моменте t
+func (c *FrozenClock) Freeze(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.frozen = t
+}
+
+// Unfreeze возвращает системное время
+func (c *FrozenClock) Unfreeze() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.frozen = time.Time{}
+}
+
+// Frozen сообщает, заморожены ли часы
+func (c *FrozenClock) Frozen() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return !c.frozen.IsZero()
+}
+
+// ParseClockTime разбирает момент в формате YYYYMMDD (полночь пояса по умолчанию) или RFC 3339
+func ParseClockTime(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation(FormDate, s, defaultLoc); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, errors.New("invalid time, expected YYYYMMDD or RFC 3339")
+	}
+	return t, nil
+}
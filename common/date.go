@@ -10,6 +10,21 @@ import (
 
 const FormDate = "20060102"
 
+// поиск даты по правилу ограничен: календарь повторяется через 400 лет, правило
+// без совпадений за этот срок (например, праздник на каждый день) не наступит никогда
+const maxSearchYears = 400
+
+// errNoOccurrence правило не дает ни одной даты в пределах поиска
+var errNoOccurrence = errors.New("repeat rule has no occurrence")
+
+// searchLimit дата, дальше которой поиск не идет
+func searchLimit(start, now time.Time) time.Time {
+	if now.After(start) {
+		start = now
+	}
+	return start.AddDate(maxSearchYears, 0, 0)
+}
+
 func NextDate(now time.Time, dateStr string, repeat string) (string, error) {
 	date, err := time.Parse(FormDate, dateStr)
 	if err != nil {
@@ -24,22 +39,53 @@ func NextDate(now time.Time, dateStr string, repeat string) (string, error) {
 		return date.Format(FormDate), nil
 	}
 
-	parts := strings.Fields(repeat)
-	if len(parts) == 0 {
-		return "", errors.New("invalid repeat format")
+	rule, err := ParseRepeat(repeat)
+	if err != nil {
+		return "", err
+	}
+
+	// after-done: отсчитываем от сегодняшнего дня, а не от даты задачи
+	if rule.FromDone {
+		date = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	nextDate, err := nextByRule(rule, date, now)
+	if err != nil {
+		return "", err
 	}
 
+	// перенос на рабочий день, если задана политика сдвига
+	if rule.Shift != "" {
+		nextDate, err = shiftToWorkday(rule, nextDate, now)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// серия закончилась по дате until
+	if !rule.Until.IsZero() && nextDate.After(rule.Until) {
+		return "", ErrRepeatFinished
+	}
+
+	return nextDate.Format(FormDate), nil
+}
+
+// nextByRule вычисляет следующую дату после now по правилу без учета сдвигов и until
+func nextByRule(rule *RepeatRule, date, now time.Time) (time.Time, error) {
+	args := rule.Args
+
 	var nextDate time.Time
 
-	switch parts[0] {
+	switch rule.Kind {
 	case "d":
-		if len(parts) != 2 {
-			return "", errors.New("invalid d format")
+		// у d интервал задается самим правилом
+		if len(args) != 1 || rule.Interval != 1 {
+			return time.Time{}, errors.New("invalid d format")
 		}
-		days, err := strconv.Atoi(parts[1])
+		days, err := strconv.Atoi(args[0])
 		if err != nil || days <= 0 || days > 400 {
 			log.Println("error: ", err)
-			return "", errors.New("invalid day interval")
+			return time.Time{}, errors.New("invalid day interval")
 		}
 
 		nextDate = date
@@ -51,14 +97,17 @@ func NextDate(now time.Time, dateStr string, repeat string) (string, error) {
 		}
 
 	case "y":
+		if len(args) != 0 {
+			return time.Time{}, errors.New("invalid y format")
+		}
 		nextDate = date
-		// Если дата уже в будущем, добавляем год
+		// Если дата уже в будущем, добавляем интервал лет
 		if AfterNow(nextDate, now) {
-			nextDate = nextDate.AddDate(1, 0, 0)
+			nextDate = nextDate.AddDate(rule.Interval, 0, 0)
 		} else {
-			// Иначе ищем следующий год, когда дата будет в будущем
+			// Иначе ищем следующий год серии, когда дата будет в будущем
 			for !AfterNow(nextDate, now) {
-				nextDate = nextDate.AddDate(1, 0, 0)
+				nextDate = nextDate.AddDate(rule.Interval, 0, 0)
 			}
 		}
 		// Коррекция для 29 февраля в невисокосный год
@@ -67,30 +116,56 @@ func NextDate(now time.Time, dateStr string, repeat string) (string, error) {
 		}
 
 	case "w":
-		if len(parts) != 2 {
-			return "", errors.New("invalid w format")
+		if len(args) != 1 {
+			return time.Time{}, errors.New("invalid w format")
 		}
-		weekdays, err := parseWeekdays(parts[1])
+		weekdays, err := parseWeekdays(args[0])
 		if err != nil {
-			return "", err
+			return time.Time{}, err
+		}
+		nextDate, err = findNextWeekday(date, now, weekdays, rule.Interval)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+	case "wd":
+		// каждый рабочий день по производственному календарю
+		if len(args) != 0 || rule.Interval != 1 {
+			return time.Time{}, errors.New("invalid wd format")
+		}
+		limit := searchLimit(date, now)
+		nextDate = date
+		for {
+			nextDate = nextDate.AddDate(0, 0, 1)
+			if AfterNow(nextDate, now) && calendar.IsWorkday(nextDate) {
+				break
+			}
+			if nextDate.After(limit) {
+				return time.Time{}, errNoOccurrence
+			}
 		}
-		nextDate = findNextWeekday(date, now, weekdays)
 
 	case "m":
-		if len(parts) < 2 {
-			return "", errors.New("invalid m format")
+		if len(args) < 1 || len(args) > 2 {
+			return time.Time{}, errors.New("invalid m format")
 		}
-		days, months, err := parseMonthRules(parts[1:])
+		days, ordinals, months, err := parseMonthRules(args)
 		if err != nil {
-			return "", err
+			return time.Time{}, err
+		}
+		if !intervalMatchesMonths(date, months, rule.Interval) {
+			return time.Time{}, errors.New("repeat interval never falls on the listed months")
+		}
+		nextDate, err = findNextMonthDay(date, now, days, ordinals, months, rule.Interval)
+		if err != nil {
+			return time.Time{}, err
 		}
-		nextDate = findNextMonthDay(date, now, days, months)
 
 	default:
-		return "", errors.New("unsupported repeat format")
+		return time.Time{}, errors.New("unsupported repeat format")
 	}
 
-	return nextDate.Format(FormDate), nil
+	return nextDate, nil
 }
 
 func AfterNow(date, now time.Time) bool {
@@ -116,16 +191,48 @@ func parseWeekdays(s string) (map[int]bool, error) {
 	return weekdays, nil
 }
 
-func parseMonthRules(parts []string) (map[int]bool, map[int]bool, error) {
+// ordinalWeekday n-й день недели месяца: "2tue" — второй вторник, "-1fri" — последняя пятница
+type ordinalWeekday struct {
+	n       int // 1..5 от начала месяца, -1..-5 от конца
+	weekday int // 1 — понедельник, 7 — воскресенье
+}
+
+var weekdayNames = map[string]int{
+	"mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6, "sun": 7,
+}
+
+func parseOrdinalWeekday(s string) (ordinalWeekday, bool) {
+	if len(s) < 4 {
+		return ordinalWeekday{}, false
+	}
+	name := strings.ToLower(s[len(s)-3:])
+	weekday, ok := weekdayNames[name]
+	if !ok {
+		return ordinalWeekday{}, false
+	}
+	n, err := strconv.Atoi(s[:len(s)-3])
+	if err != nil || n == 0 || n < -5 || n > 5 {
+		return ordinalWeekday{}, false
+	}
+	return ordinalWeekday{n: n, weekday: weekday}, true
+}
+
+func parseMonthRules(parts []string) (map[int]bool, []ordinalWeekday, map[int]bool, error) {
 	daysStr := strings.Split(parts[0], ",")
 	days := make(map[int]bool)
+	var ordinals []ordinalWeekday
 	for _, dayStr := range daysStr {
+		// n-й день недели, например 2tue или -1fri
+		if ow, ok := parseOrdinalWeekday(dayStr); ok {
+			ordinals = append(ordinals, ow)
+			continue
+		}
 		day, err := strconv.Atoi(dayStr)
 		if err != nil {
-			return nil, nil, errors.New("invalid day in month")
+			return nil, nil, nil, errors.New("invalid day in month")
 		}
 		if day < -2 || day > 31 || day == 0 {
-			return nil, nil, errors.New("invalid day in month")
+			return nil, nil, nil, errors.New("invalid day in month")
 		}
 		days[day] = true
 	}
@@ -137,35 +244,111 @@ func parseMonthRules(parts []string) (map[int]bool, map[int]bool, error) {
 		for _, monthStr := range monthsStr {
 			month, err := strconv.Atoi(monthStr)
 			if err != nil || month < 1 || month > 12 {
-				return nil, nil, errors.New("invalid month")
+				return nil, nil, nil, errors.New("invalid month")
 			}
 			months[month] = true
 		}
+		// число, которого нет ни в одном из месяцев, никогда не наступит
+		for day := range days {
+			if day > 0 && !dayInMonths(day, months) {
+				return nil, nil, nil, errors.New("invalid day in month")
+			}
+		}
 	}
 
-	return days, months, nil
+	return days, ordinals, months, nil
 }
 
-func findNextWeekday(start, now time.Time, weekdays map[int]bool) time.Time {
-	date := start
-	for {
-		date = date.AddDate(0, 0, 1)
+// dayInMonths есть ли число day хотя бы в одном из месяцев, февраль считается високосным
+func dayInMonths(day int, months map[int]bool) bool {
+	for month := range months {
+		if day <= time.Date(2024, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day() {
+			return true
+		}
+	}
+	return false
+}
+
+// weeksBetween число недель (с понедельника) между неделями двух дат
+func weeksBetween(from, to time.Time) int {
+	monday := func(t time.Time) time.Time {
+		wd := int(t.Weekday())
+		if wd == 0 { // Воскресенье
+			wd = 7
+		}
+		return time.Date(t.Year(), t.Month(), t.Day()-wd+1, 0, 0, 0, 0, time.UTC)
+	}
+	return int(monday(to).Sub(monday(from)).Hours()/24) / 7
+}
+
+// monthsBetween число календарных месяцев между двумя датами
+func monthsBetween(from, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
+
+// intervalMatchesMonths попадает ли хоть один из месяцев в серию "каждые interval месяцев"
+// от месяца start: через 12k месяцев номер месяца не меняется, поэтому достижимы только
+// месяцы, отстоящие от start на кратное НОД(12, interval)
+func intervalMatchesMonths(start time.Time, months map[int]bool, interval int) bool {
+	if len(months) == 0 {
+		return true
+	}
+	step := gcd(12, interval)
+	for month := range months {
+		if (month-int(start.Month())+12)%step == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// findNextWeekday ищет следующий день недели; interval отсчитывает недели от исходной даты
+func findNextWeekday(start, now time.Time, weekdays map[int]bool, interval int) (time.Time, error) {
+	limit := searchLimit(start, now)
+	for date := start.AddDate(0, 0, 1); !date.After(limit); date = date.AddDate(0, 0, 1) {
 		if AfterNow(date, now) {
+			if weeksBetween(start, date)%interval != 0 {
+				continue
+			}
 			wd := int(date.Weekday())
 			if wd == 0 { // Воскресенье
 				wd = 7
 			}
 			if weekdays[wd] {
-				return date
+				return date, nil
 			}
 		}
 	}
+	return time.Time{}, errNoOccurrence
+}
+
+// matchOrdinal проверяет, является ли день n-м днем недели месяца
+func matchOrdinal(date time.Time, lastDay int, ordinals []ordinalWeekday) bool {
+	wd := int(date.Weekday())
+	if wd == 0 { // Воскресенье
+		wd = 7
+	}
+	fromStart := (date.Day()-1)/7 + 1
+	fromEnd := -((lastDay-date.Day())/7 + 1)
+	for _, ow := range ordinals {
+		if ow.weekday == wd && (ow.n == fromStart || ow.n == fromEnd) {
+			return true
+		}
+	}
+	return false
 }
 
-func findNextMonthDay(start, now time.Time, days, months map[int]bool) time.Time {
-	date := start
-	for {
-		date = date.AddDate(0, 0, 1)
+// findNextMonthDay ищет следующий день месяца; interval отсчитывает месяцы от исходной даты
+func findNextMonthDay(start, now time.Time, days map[int]bool, ordinals []ordinalWeekday, months map[int]bool, interval int) (time.Time, error) {
+	limit := searchLimit(start, now)
+	for date := start.AddDate(0, 0, 1); !date.After(limit); date = date.AddDate(0, 0, 1) {
 		if AfterNow(date, now) {
 			month := int(date.Month())
 			day := date.Day()
@@ -176,17 +359,27 @@ func findNextMonthDay(start, now time.Time, days, months map[int]bool) time.Time
 				continue
 			}
 
+			// Пропускаем месяцы вне интервала
+			if monthsBetween(start, date)%interval != 0 {
+				continue
+			}
+
 			// Проверяем специальные дни (-1, -2)
 			if days[-1] && day == lastDay {
-				return date
+				return date, nil
 			}
 			if days[-2] && day == lastDay-1 {
-				return date
+				return date, nil
 			}
 
 			// Проверяем обычные дни
 			if days[day] {
-				return date
+				return date, nil
+			}
+
+			// Проверяем n-е дни недели (2tue, -1fri)
+			if matchOrdinal(date, lastDay, ordinals) {
+				return date, nil
 			}
 
 			// Обработка дней > lastDay (например, "m 31")
@@ -194,14 +387,21 @@ func findNextMonthDay(start, now time.Time, days, months map[int]bool) time.Time
 				for d := range days {
 					if d > lastDay {
 						nextMonth := time.Date(date.Year(), date.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+						if len(months) > 0 && !months[int(nextMonth.Month())] {
+							break
+						}
+						if monthsBetween(start, nextMonth)%interval != 0 {
+							break
+						}
 						lastDayNextMonth := time.Date(nextMonth.Year(), nextMonth.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
 						if d <= lastDayNextMonth {
-							return time.Date(nextMonth.Year(), nextMonth.Month(), d, 0, 0, 0, 0, time.UTC)
+							return time.Date(nextMonth.Year(), nextMonth.Month(), d, 0, 0, 0, 0, time.UTC), nil
 						}
-						return time.Date(nextMonth.Year(), nextMonth.Month(), lastDayNextMonth, 0, 0, 0, 0, time.UTC)
+						return time.Date(nextMonth.Year(), nextMonth.Month(), lastDayNextMonth, 0, 0, 0, 0, time.UTC), nil
 					}
 				}
 			}
 		}
 	}
+	return time.Time{}, errNoOccurrence
 }
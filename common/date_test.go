@@ -0,0 +1,193 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func mustDate(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse(FormDate, s)
+	if err != nil {
+		t.Fatalf("bad date %q: %v", s, err)
+	}
+	return d
+}
+
+// nextDateCase ожидаемый результат NextDate, пустой want — ошибка правила
+type nextDateCase struct {
+	date   string
+	repeat string
+	want   string
+}
+
+func checkNextDate(t *testing.T, now string, cases []nextDateCase) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.date+" "+tc.repeat, func(t *testing.T) {
+			got, err := NextDate(mustDate(t, now), tc.date, tc.repeat)
+			if tc.want == "" {
+				if err == nil {
+					t.Fatalf("NextDate() = %q, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NextDate() error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("NextDate() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+// базовые правила d, y, w, m
+func TestNextDate(t *testing.T) {
+	checkNextDate(t, "20240126", []nextDateCase{
+		// без правила дата не раньше сегодняшней
+		{"20240201", "", "20240201"},
+		{"20240101", "", "20240126"},
+
+		{"20240113", "d", ""},
+		{"20240113", "d 7", "20240127"},
+		{"20240120", "d 20", "20240209"},
+		{"20240202", "d 30", "20240303"},
+		{"20240320", "d 401", ""},
+		{"20231225", "d 12", "20240130"},
+		{"20240228", "d 1", "20240229"},
+		{"20240126", "d 1", "20240127"},
+		{"20240126", "d 0", ""},
+		{"20240126", "d x", ""},
+		{"20240126", "d 1 2", ""},
+
+		{"20231106", "y", "20241106"},
+		{"20240127", "y", "20250127"},
+		{"20230101", "y", "20250101"},
+		{"20240229", "y", "20250301"},
+		{"20240126", "y 1", ""},
+
+		{"20240126", "w", ""},
+		{"20240126", "w 1,2,3", "20240129"},
+		{"20240125", "w 5", "20240202"},
+		{"20240126", "w 7", "20240128"},
+		{"20230126", "w 4,5", "20240201"},
+		{"20240126", "w 8,4,5", ""},
+		{"20240126", "w 0", ""},
+
+		{"20240126", "m", ""},
+		{"20231106", "m 13", "20240213"},
+		{"20240120", "m 40,11,19", ""},
+		{"20240116", "m 16,5", "20240205"},
+		{"20240126", "m 25,26,7", "20240207"},
+		{"20240409", "m 31", "20240531"},
+		{"20240329", "m 10,17 12,8,1", "20240810"},
+		{"20230311", "m 07,19 05,6", "20240507"},
+		{"20230311", "m 1 1,2", "20240201"},
+		{"20240127", "m -1", "20240131"},
+		{"20240222", "m -2", "20240228"},
+		{"20240222", "m -2,-3", ""},
+		{"20240326", "m -1,-2", "20240330"},
+		{"20240201", "m -1,18", "20240218"},
+		{"20240126", "m 1 13", ""},
+		{"20240126", "m 0", ""},
+
+		{"20240126", "k 34", ""},
+		{"2024012", "d 1", ""},
+	})
+}
+
+// n-й день недели: в одних месяцах он встречается четыре раза, в других пять
+func TestNextDateOrdinalWeekday(t *testing.T) {
+	cases := []struct {
+		now    string
+		date   string
+		repeat string
+		want   string
+	}{
+		// пятниц пять в марте и мае 2024, четыре в январе, феврале и апреле
+		{"20240101", "20240101", "m 5fri", "20240329"},
+		{"20240329", "20240329", "m 5fri", "20240531"},
+		{"20240101", "20240101", "m -1fri", "20240126"},
+		{"20240126", "20240126", "m -1fri", "20240223"},
+		{"20240223", "20240223", "m -1fri", "20240329"},
+		// пятая с конца пятница — первая пятница месяца, где их пять
+		{"20240101", "20240101", "m -5fri", "20240301"},
+		{"20240301", "20240301", "m -5fri", "20240503"},
+		{"20240101", "20240101", "m 2tue", "20240109"},
+		{"20240109", "20240109", "m 2tue", "20240213"},
+		{"20240213", "20240213", "m 2tue", "20240312"},
+		{"20240101", "20240101", "m 1mon,-1sun", "20240128"},
+		{"20240128", "20240128", "m 1mon,-1sun", "20240205"},
+		// пять пятниц в феврале бывают только в високосный год с пятницей 1 февраля
+		{"20240101", "20240101", "m 5fri 2", "20360229"},
+		{"20240101", "20240101", "m 2tue 6,7", "20240611"},
+		{"20240101", "20240101", "m 6fri", ""},
+		{"20240101", "20240101", "m 0fri", ""},
+		{"20240101", "20240101", "m -6fri", ""},
+		{"20240101", "20240101", "m 2fry", ""},
+		{"20240101", "20240101", "m fri", ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.now+" "+tc.repeat, func(t *testing.T) {
+			checkNextDate(t, tc.now, []nextDateCase{{tc.date, tc.repeat, tc.want}})
+		})
+	}
+}
+
+// число месяца, которого нет в части месяцев
+func TestNextDateShortMonths(t *testing.T) {
+	checkNextDate(t, "20240126", []nextDateCase{
+		{"20240126", "m 31", "20240131"},
+		{"20240131", "m 31", "20240331"},
+		{"20240409", "m 31", "20240531"},
+		{"20240126", "m 30", "20240130"},
+		{"20240130", "m 30", "20240330"},
+		// 29 февраля — только в високосный год
+		{"20240301", "m 29 2", "20280229"},
+		{"20240126", "m 31 1,2,3", "20240131"},
+		{"20240131", "m 31 2,3", "20240331"},
+		{"20240126", "m 31 4,5", "20240531"},
+		{"20240126", "m 30,31 2", ""},
+		{"20240126", "m 31 2", ""},
+		{"20240126", "m 31 4,6,9,11", ""},
+	})
+}
+
+// интервал /N отсчитывается от исходной даты серии
+func TestNextDateInterval(t *testing.T) {
+	checkNextDate(t, "20240126", []nextDateCase{
+		{"20240101", "w 1 /2", "20240129"},
+		{"20240108", "w 1 /2", "20240205"},
+		{"20240115", "m 15 /3", "20240415"},
+		{"20231215", "m 15 /2", "20240215"},
+		{"20230301", "y /2", "20250301"},
+		{"20240315", "m 15 1 /2", "20250115"},
+		{"20240215", "m 5fri 2 /12", "20360229"},
+		// от февраля через каждые два месяца январь не наступит никогда
+		{"20240215", "m 15 1 /2", ""},
+		{"20240215", "m 15 1,3 /12", ""},
+		{"20240215", "m 15 2 /12", "20250215"},
+	})
+}
+
+// все дни календаря — праздники: поиск рабочего дня должен остановиться
+func TestNextDateNoWorkdays(t *testing.T) {
+	saved := calendar
+	defer func() { calendar = saved }()
+
+	now := mustDate(t, "20240126")
+	holidays := make(map[string]bool)
+	for d := now.AddDate(-1, 0, 0); d.Before(now.AddDate(maxSearchYears+2, 0, 0)); d = d.AddDate(0, 0, 1) {
+		holidays[d.Format(FormDate)] = true
+	}
+	calendar = &Calendar{holidays: holidays}
+
+	for _, repeat := range []string{"wd", "d 1 next-workday", "m 1 prev-workday", "y next-workday"} {
+		t.Run(repeat, func(t *testing.T) {
+			if got, err := NextDate(now, "20240126", repeat); err == nil {
+				t.Errorf("NextDate() = %q, want error", got)
+			}
+		})
+	}
+}
@@ -33,7 +33,7 @@ func InitDB() error {
 	}
 
 	// создаю таблицу и индекс, если их нет
-	if err := db.AutoMigrate(&md.Task{}); err != nil {
+	if err := db.AutoMigrate(&md.Project{}, &md.Task{}, &md.MissedOccurrence{}, &md.Tag{}, &md.TaskTag{}, &md.TaskDependency{}, &md.StatusChange{}, &md.TaskRevision{}, &md.AuditEvent{}, &md.Attachment{}); err != nil {
 		return fmt.Errorf("failed to migrate schema: %w", err)
 	}
 	return nil
@@ -0,0 +1,47 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const FormTime = "15:04"
+
+// пояс для задач без явного tz
+var defaultLoc = time.Local
+
+// SetDefaultLocation задает пояс по умолчанию, пустая строка — локальный пояс сервера
+func SetDefaultLocation(tz string) error {
+	if tz == "" {
+		defaultLoc = time.Local
+		return nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return fmt.Errorf("invalid default time zone %q: %w", tz, err)
+	}
+	defaultLoc = loc
+	return nil
+}
+
+// Location возвращает часовой пояс задачи
+func Location(tz string) (*time.Location, error) {
+	if tz == "" {
+		return defaultLoc, nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, errors.New("invalid time zone")
+	}
+	return loc, nil
+}
+
+// NowIn переводит текущий момент в пояс задачи, чтобы "сегодня" считалось по ее часам
+func NowIn(now time.Time, tz string) (time.Time, error) {
+	loc, err := Location(tz)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return now.In(loc), nil
+}
@@ -0,0 +1,288 @@
+package common
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrRepeatFinished — серия повторений исчерпана, следующей даты нет
+var ErrRepeatFinished = errors.New("repeat series finished")
+
+// RepeatRule разобранное правило повторения
+type RepeatRule struct {
+	Kind     string    // d, y, w, m, wd
+	Args     []string  // аргументы правила без модификаторов
+	Interval int       // каждые N недель/месяцев/лет от исходной даты, по умолчанию 1
+	Shift    string    // перенос с выходного: next-workday или prev-workday
+	FromDone bool      // отсчет от дня выполнения, а не от даты по расписанию
+	Until    time.Time // последняя допустимая дата, нулевая — без ограничения
+	Count    int       // число повторений, 0 — без ограничения
+}
+
+// режимы отсчета повторений
+const (
+	RepeatModeSchedule   = "schedule"   // от даты по расписанию
+	RepeatModeCompletion = "completion" // от дня выполнения
+)
+
+// политики для пропущенных повторений
+const (
+	CatchUpSkip        = "skip"        // пропущенные повторения отбрасываются
+	CatchUpMaterialize = "materialize" // создаются разовые просроченные задачи
+	CatchUpHistory     = "history"     // записываются в историю пропусков
+)
+
+// максимальное число пропущенных повторений, обрабатываемых за раз
+const maxMissed = 1000
+
+// модификатор правила для отсчета от дня выполнения
+const afterDone = "after-done"
+
+// политики переноса даты на рабочий день
+const (
+	ShiftNextWorkday = "next-workday"
+	ShiftPrevWorkday = "prev-workday"
+)
+
+// максимальный интервал /N
+const maxRepeatInterval = 100
+
+// ParseRepeat разбирает правило вида
+// "<правило> [/N] [next-workday|prev-workday] [after-done] [until YYYYMMDD] [count N]"
+func ParseRepeat(repeat string) (*RepeatRule, error) {
+	parts := strings.Fields(repeat)
+	if len(parts) == 0 {
+		return nil, errors.New("invalid repeat format")
+	}
+
+	rule := &RepeatRule{Kind: parts[0], Interval: 1}
+	modifiers := false
+	for i := 1; i < len(parts); i++ {
+		switch parts[i] {
+		case "until":
+			if i+1 >= len(parts) || !rule.Until.IsZero() {
+				return nil, errors.New("invalid until format")
+			}
+			until, err := time.Parse(FormDate, parts[i+1])
+			if err != nil {
+				return nil, errors.New("invalid until date")
+			}
+			rule.Until = until
+			modifiers = true
+			i++
+		case "count":
+			if i+1 >= len(parts) || rule.Count != 0 {
+				return nil, errors.New("invalid count format")
+			}
+			count, err := strconv.Atoi(parts[i+1])
+			if err != nil || count <= 0 {
+				return nil, errors.New("invalid count")
+			}
+			rule.Count = count
+			modifiers = true
+			i++
+		case afterDone:
+			if rule.FromDone {
+				return nil, errors.New("invalid after-done format")
+			}
+			rule.FromDone = true
+			modifiers = true
+		case ShiftNextWorkday, ShiftPrevWorkday:
+			if rule.Shift != "" {
+				return nil, errors.New("invalid workday shift")
+			}
+			rule.Shift = parts[i]
+			modifiers = true
+		default:
+			if strings.HasPrefix(parts[i], "/") {
+				interval, err := strconv.Atoi(parts[i][1:])
+				if err != nil || interval <= 0 || interval > maxRepeatInterval || rule.Interval != 1 {
+					return nil, errors.New("invalid repeat interval")
+				}
+				rule.Interval = interval
+				modifiers = true
+				continue
+			}
+			// модификаторы идут только после аргументов правила
+			if modifiers {
+				return nil, errors.New("invalid repeat format")
+			}
+			rule.Args = append(rule.Args, parts[i])
+		}
+	}
+	return rule, nil
+}
+
+// RepeatMode определяет режим отсчета: модификатор after-done в правиле
+// включает отсчет от выполнения, иначе сохраняется явно заданный режим
+func RepeatMode(repeat, mode string) (string, error) {
+	switch mode {
+	case "":
+		mode = RepeatModeSchedule
+	case RepeatModeSchedule, RepeatModeCompletion:
+	default:
+		return "", errors.New("invalid repeat mode")
+	}
+	if repeat == "" {
+		return mode, nil
+	}
+	rule, err := ParseRepeat(repeat)
+	if err != nil {
+		return "", err
+	}
+	if rule.FromDone {
+		return RepeatModeCompletion, nil
+	}
+	return mode, nil
+}
+
+// CheckCatchUp проверяет политику пропущенных повторений, пустая — skip
+func CheckCatchUp(policy string) (string, error) {
+	switch policy {
+	case "":
+		return CatchUpSkip, nil
+	case CatchUpSkip, CatchUpMaterialize, CatchUpHistory:
+		return policy, nil
+	default:
+		return "", errors.New("invalid catch-up policy")
+	}
+}
+
+// MissedOccurrences перечисляет повторения серии строго после from и не позже to.
+// remaining — сколько повторений осталось, включая from (0 — без ограничения): каждое
+// найденное повторение расходует одно, и серия не выходит за count. left — счетчик для
+// последнего найденного повторения как текущего, его передают в NextOccurrence.
+func MissedOccurrences(from string, to time.Time, repeat string, remaining int, except []string) (missed []string, left int, err error) {
+	if repeat == "" {
+		return nil, remaining, nil
+	}
+	left = remaining
+	cur := from
+	for len(missed) < maxMissed && left != 1 {
+		curDate, err := time.Parse(FormDate, cur)
+		if err != nil {
+			return nil, 0, errors.New("invalid date format")
+		}
+		next, err := NextDateExcept(curDate, cur, repeat, except)
+		if errors.Is(err, ErrRepeatFinished) {
+			break
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		nextDate, err := time.Parse(FormDate, next)
+		if err != nil {
+			return nil, 0, err
+		}
+		if AfterNow(nextDate, to) {
+			break
+		}
+		missed = append(missed, next)
+		cur = next
+		if left > 1 {
+			left--
+		}
+	}
+	return missed, left, nil
+}
+
+// NextDateExcept как NextDate, но пропускает даты-исключения серии
+func NextDateExcept(now time.Time, dateStr, repeat string, except []string) (string, error) {
+	next, err := NextDate(now, dateStr, repeat)
+	for err == nil && containsDate(except, next) {
+		// ищем следующее повторение строго после исключенной даты
+		var skipped time.Time
+		skipped, err = time.Parse(FormDate, next)
+		if err != nil {
+			return "", err
+		}
+		next, err = NextDate(skipped, next, repeat)
+	}
+	return next, err
+}
+
+func containsDate(dates []string, date string) bool {
+	for _, d := range dates {
+		if d == date {
+			return true
+		}
+	}
+	return false
+}
+
+// NextOccurrence вычисляет дату следующего повторения при выполнении задачи.
+// В режиме completion интервал отсчитывается от дня выполнения (now).
+// remaining — сколько повторений осталось, включая текущее (0 — без ограничения).
+// finished == true означает, что серия исчерпана и задачу нужно закрыть.
+func NextOccurrence(now time.Time, dateStr, repeat, mode string, remaining int, except []string) (next string, left int, finished bool, err error) {
+	if repeat == "" || remaining == 1 {
+		return "", 0, true, nil
+	}
+
+	if mode == RepeatModeCompletion {
+		dateStr = now.Format(FormDate)
+	}
+
+	next, err = NextDateExcept(now, dateStr, repeat, except)
+	if errors.Is(err, ErrRepeatFinished) {
+		return "", 0, true, nil
+	}
+	if err != nil {
+		return "", 0, false, err
+	}
+
+	if remaining > 1 {
+		left = remaining - 1
+	}
+	return next, left, false, nil
+}
+
+// IsOccurrence сообщает, приходится ли на date одно из оставшихся повторений серии,
+// текущее повторение которой — from. remaining и except — как в NextOccurrence.
+// В режиме completion будущие даты зависят от дня выполнения, подходит любая дата после from.
+func IsOccurrence(from, date, repeat, mode string, remaining int, except []string) (bool, error) {
+	day, err := time.Parse(FormDate, date)
+	if err != nil {
+		return false, errors.New("invalid date format")
+	}
+	if repeat == "" || date <= from {
+		return false, nil
+	}
+	if mode == RepeatModeCompletion {
+		return true, nil
+	}
+
+	// первое повторение после предыдущего дня должно прийтись ровно на date
+	next, err := NextDate(day.AddDate(0, 0, -1), from, repeat)
+	if errors.Is(err, ErrRepeatFinished) {
+		return false, nil
+	}
+	if err != nil || next != date {
+		return false, err
+	}
+	if remaining == 0 {
+		return true, nil
+	}
+
+	// повторения до date расходуют счетчик, исключения — нет
+	cur := from
+	for n := 1; n < remaining; n++ {
+		curDate, err := time.Parse(FormDate, cur)
+		if err != nil {
+			return false, errors.New("invalid date format")
+		}
+		cur, err = NextDateExcept(curDate, cur, repeat, except)
+		if errors.Is(err, ErrRepeatFinished) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		if cur >= date {
+			return cur == date, nil
+		}
+	}
+	return false, nil
+}
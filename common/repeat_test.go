@@ -0,0 +1,244 @@
+package common
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseRepeat(t *testing.T) {
+	cases := []struct {
+		repeat string
+		want   *RepeatRule
+	}{
+		{"d 7", &RepeatRule{Kind: "d", Args: []string{"7"}, Interval: 1}},
+		{"y", &RepeatRule{Kind: "y", Interval: 1}},
+		{"m 1,-1 1,6", &RepeatRule{Kind: "m", Args: []string{"1,-1", "1,6"}, Interval: 1}},
+		{"w 1,3 /2", &RepeatRule{Kind: "w", Args: []string{"1,3"}, Interval: 2}},
+		{"wd next-workday", &RepeatRule{Kind: "wd", Interval: 1, Shift: ShiftNextWorkday}},
+		{"m 15 prev-workday", &RepeatRule{Kind: "m", Args: []string{"15"}, Interval: 1, Shift: ShiftPrevWorkday}},
+		{"d 3 after-done", &RepeatRule{Kind: "d", Args: []string{"3"}, Interval: 1, FromDone: true}},
+		{"d 1 until 20240301", &RepeatRule{Kind: "d", Args: []string{"1"}, Interval: 1, Until: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)}},
+		{"w 5 count 10", &RepeatRule{Kind: "w", Args: []string{"5"}, Interval: 1, Count: 10}},
+		{"y /4 until 20400101 count 3", &RepeatRule{Kind: "y", Interval: 4, Until: time.Date(2040, 1, 1, 0, 0, 0, 0, time.UTC), Count: 3}},
+		// ошибки разбора
+		{"", nil},
+		{"   ", nil},
+		{"d 1 until", nil},
+		{"d 1 until 2024-03-01", nil},
+		{"d 1 until 20240301 until 20240401", nil},
+		{"d 1 count", nil},
+		{"d 1 count 0", nil},
+		{"d 1 count -2", nil},
+		{"d 1 count 2 count 3", nil},
+		{"d 1 after-done after-done", nil},
+		{"m 1 next-workday prev-workday", nil},
+		{"w 1 /0", nil},
+		{"w 1 /x", nil},
+		{"w 1 /101", nil},
+		{"w 1 /2 /3", nil},
+		{"d 1 count 2 5", nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.repeat, func(t *testing.T) {
+			got, err := ParseRepeat(tc.repeat)
+			if tc.want == nil {
+				if err == nil {
+					t.Fatalf("ParseRepeat() = %+v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRepeat() error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("ParseRepeat() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRepeatMode(t *testing.T) {
+	cases := []struct {
+		repeat, mode string
+		want         string
+		wantErr      bool
+	}{
+		{"d 1", "", RepeatModeSchedule, false},
+		{"d 1", RepeatModeCompletion, RepeatModeCompletion, false},
+		{"d 1 after-done", "", RepeatModeCompletion, false},
+		{"d 1 after-done", RepeatModeSchedule, RepeatModeCompletion, false},
+		{"", "", RepeatModeSchedule, false},
+		{"d 1", "sometimes", "", true},
+		{"d 1 count 0", "", "", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.repeat+"/"+tc.mode, func(t *testing.T) {
+			got, err := RepeatMode(tc.repeat, tc.mode)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("RepeatMode() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("RepeatMode() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckCatchUp(t *testing.T) {
+	cases := []struct {
+		policy  string
+		want    string
+		wantErr bool
+	}{
+		{"", CatchUpSkip, false},
+		{CatchUpSkip, CatchUpSkip, false},
+		{CatchUpMaterialize, CatchUpMaterialize, false},
+		{CatchUpHistory, CatchUpHistory, false},
+		{"Skip", "", true},
+		{"later", "", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.policy, func(t *testing.T) {
+			got, err := CheckCatchUp(tc.policy)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("CheckCatchUp() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("CheckCatchUp() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNextOccurrence(t *testing.T) {
+	cases := []struct {
+		name      string
+		now       string
+		date      string
+		repeat    string
+		mode      string
+		remaining int
+		except    []string
+		next      string
+		left      int
+		finished  bool
+	}{
+		{name: "one-off", now: "20240126", date: "20240126", finished: true},
+		{name: "endless", now: "20240126", date: "20240120", repeat: "d 7", next: "20240127"},
+		{name: "schedule", now: "20240126", date: "20240110", repeat: "d 10", mode: RepeatModeSchedule, next: "20240130"},
+		{name: "completion", now: "20240126", date: "20240110", repeat: "d 10", mode: RepeatModeCompletion, next: "20240205"},
+		{name: "count left", now: "20240126", date: "20240126", repeat: "d 1 count 3", remaining: 3, next: "20240127", left: 2},
+		{name: "count last", now: "20240126", date: "20240126", repeat: "d 1 count 3", remaining: 1, finished: true},
+		{name: "until reached", now: "20240126", date: "20240126", repeat: "d 7 until 20240201", finished: true},
+		{name: "until inclusive", now: "20240126", date: "20240126", repeat: "d 7 until 20240202", next: "20240202"},
+		{name: "exception", now: "20240126", date: "20240126", repeat: "d 1", except: []string{"20240127", "20240128"}, next: "20240129"},
+		{name: "exception at until", now: "20240126", date: "20240126", repeat: "d 1 until 20240127", except: []string{"20240127"}, finished: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			next, left, finished, err := NextOccurrence(mustDate(t, tc.now), tc.date, tc.repeat, tc.mode, tc.remaining, tc.except)
+			if err != nil {
+				t.Fatalf("NextOccurrence() error: %v", err)
+			}
+			if next != tc.next || left != tc.left || finished != tc.finished {
+				t.Errorf("NextOccurrence() = (%q, %d, %v), want (%q, %d, %v)",
+					next, left, finished, tc.next, tc.left, tc.finished)
+			}
+		})
+	}
+
+	if _, _, _, err := NextOccurrence(mustDate(t, "20240126"), "20240126", "d 0", "", 0, nil); err == nil {
+		t.Error("NextOccurrence() with invalid rule: want error")
+	}
+}
+
+func TestNextDateUntil(t *testing.T) {
+	_, err := NextDate(mustDate(t, "20240126"), "20240120", "w 1 until 20240128")
+	if !errors.Is(err, ErrRepeatFinished) {
+		t.Errorf("NextDate() error = %v, want ErrRepeatFinished", err)
+	}
+}
+
+func TestIsOccurrence(t *testing.T) {
+	cases := []struct {
+		from, date, repeat, mode string
+		remaining                int
+		except                   []string
+		want                     bool
+	}{
+		{"20240126", "20240202", "d 7", "", 0, nil, true},
+		{"20240126", "20240203", "d 7", "", 0, nil, false},
+		{"20240126", "20240126", "d 7", "", 0, nil, false},
+		{"20240126", "20240119", "d 7", "", 0, nil, false},
+		{"20240126", "20240205", "w 1", "", 0, nil, true},
+		{"20240126", "20240206", "w 1", "", 0, nil, false},
+		{"20240101", "20240129", "w 1 /2", "", 0, nil, true},
+		{"20240101", "20240122", "w 1 /2", "", 0, nil, false},
+		{"20240126", "20240209", "d 7 until 20240205", "", 0, nil, false},
+		// count 3: текущее и еще два повторения
+		{"20240126", "20240128", "d 1 count 3", "", 3, nil, true},
+		{"20240126", "20240129", "d 1 count 3", "", 3, nil, false},
+		// исключение не расходует счетчик
+		{"20240126", "20240129", "d 1 count 3", "", 3, []string{"20240127"}, true},
+		{"20240126", "20240210", "d 7", RepeatModeCompletion, 0, nil, true},
+		{"20240126", "20240202", "", "", 0, nil, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.date+" "+tc.repeat, func(t *testing.T) {
+			got, err := IsOccurrence(tc.from, tc.date, tc.repeat, tc.mode, tc.remaining, tc.except)
+			if err != nil {
+				t.Fatalf("IsOccurrence() error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("IsOccurrence() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	if _, err := IsOccurrence("20240126", "2024-02-02", "d 7", "", 0, nil); err == nil {
+		t.Error("IsOccurrence() with invalid date: want error")
+	}
+}
+
+func TestMissedOccurrences(t *testing.T) {
+	cases := []struct {
+		name      string
+		from      string
+		to        string
+		repeat    string
+		remaining int
+		except    []string
+		want      []string
+		left      int
+	}{
+		{name: "endless", from: "20240101", to: "20240126", repeat: "d 7",
+			want: []string{"20240108", "20240115", "20240122"}},
+		{name: "inclusive to", from: "20240105", to: "20240126", repeat: "d 7",
+			want: []string{"20240112", "20240119", "20240126"}},
+		{name: "none", from: "20240122", to: "20240126", repeat: "d 7"},
+		{name: "one-off", from: "20240101", to: "20240126"},
+		{name: "until", from: "20240101", to: "20240126", repeat: "d 7 until 20240115",
+			want: []string{"20240108", "20240115"}},
+		{name: "exception", from: "20240101", to: "20240126", repeat: "d 7", except: []string{"20240115"},
+			want: []string{"20240108", "20240122"}},
+		// каждое повторение расходует счетчик
+		{name: "count left", from: "20240101", to: "20240126", repeat: "d 7 count 10", remaining: 10,
+			want: []string{"20240108", "20240115", "20240122"}, left: 7},
+		{name: "count exhausted", from: "20240101", to: "20240126", repeat: "d 7 count 3", remaining: 3,
+			want: []string{"20240108", "20240115"}, left: 1},
+		{name: "count last", from: "20240101", to: "20240126", repeat: "d 7 count 3", remaining: 1, left: 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			missed, left, err := MissedOccurrences(tc.from, mustDate(t, tc.to), tc.repeat, tc.remaining, tc.except)
+			if err != nil {
+				t.Fatalf("MissedOccurrences() error: %v", err)
+			}
+			if !reflect.DeepEqual(missed, tc.want) || left != tc.left {
+				t.Errorf("MissedOccurrences() = (%v, %d), want (%v, %d)", missed, left, tc.want, tc.left)
+			}
+		})
+	}
+}
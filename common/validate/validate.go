@@ -0,0 +1,130 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// скомпилированные шаблоны из правил, ключ — текст шаблона
+var patterns sync.Map
+
+// Message проверяет сообщение по ограничениям (scheduler.rules) из proto-описания.
+// Вложенные сообщения проверяются рекурсивно, поле называется путем через точку
+// (task.title, exceptions[0]). Нарушения возвращаются как FieldError через errors.Join.
+func Message(m proto.Message) error {
+	return errors.Join(checkMessage(m.ProtoReflect(), "")...)
+}
+
+// Partial проверяет m как Message, но нарушения в полях вложенного сообщения prefix
+// (например "task.") учитываются только для полей из fields — так проверяется частичное
+// обновление, остальные поля клиент не передавал. Пустой fields — проверка всех полей.
+func Partial(m proto.Message, prefix string, fields []string) error {
+	errs := checkMessage(m.ProtoReflect(), "")
+	if len(fields) == 0 {
+		return errors.Join(errs...)
+	}
+	var result []error
+	for _, err := range errs {
+		var fe *apperrors.FieldError
+		if errors.As(err, &fe) {
+			if name, ok := strings.CutPrefix(fe.Field, prefix); ok {
+				// tags[0] и checklist[1].text относятся к полям tags и checklist
+				name, _, _ = strings.Cut(name, "[")
+				name, _, _ = strings.Cut(name, ".")
+				if !slices.Contains(fields, name) {
+					continue
+				}
+			}
+		}
+		result = append(result, err)
+	}
+	return errors.Join(result...)
+}
+
+func checkMessage(m protoreflect.Message, prefix string) []error {
+	var errs []error
+	fields := m.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := prefix + string(fd.Name())
+		rules, _ := proto.GetExtension(fd.Options(), pb.E_Rules).(*pb.FieldRules)
+
+		switch {
+		case fd.IsMap():
+			continue
+		case fd.IsList():
+			list := m.Get(fd).List()
+			if rules.GetRequired() && list.Len() == 0 {
+				errs = append(errs, apperrors.NewFieldError(name, apperrors.ErrFieldRequired))
+			}
+			for j := 0; j < list.Len(); j++ {
+				errs = append(errs, checkValue(fd, list.Get(j), rules, fmt.Sprintf("%s[%d]", name, j))...)
+			}
+		case !m.Has(fd):
+			// у proto3 скаляров Has ложно для нулевого значения
+			if rules.GetRequired() {
+				errs = append(errs, apperrors.NewFieldError(name, apperrors.ErrFieldRequired))
+			}
+		default:
+			errs = append(errs, checkValue(fd, m.Get(fd), rules, name)...)
+		}
+	}
+	return errs
+}
+
+func checkValue(fd protoreflect.FieldDescriptor, v protoreflect.Value, rules *pb.FieldRules, name string) []error {
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return checkMessage(v.Message(), name+".")
+	case protoreflect.StringKind:
+		if err := checkString(v.String(), rules); err != nil {
+			return []error{apperrors.NewFieldError(name, err)}
+		}
+	}
+	return nil
+}
+
+func checkString(s string, rules *pb.FieldRules) error {
+	if rules == nil {
+		return nil
+	}
+	if rules.GetRequired() && strings.TrimSpace(s) == "" {
+		return apperrors.ErrFieldRequired
+	}
+	if n := rules.GetMaxLen(); n > 0 && utf8.RuneCountInString(s) > int(n) {
+		return apperrors.ErrFieldTooLong.With("max", strconv.Itoa(int(n)))
+	}
+	if p := rules.GetPattern(); p != "" && s != "" {
+		re, err := compile(p)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(s) {
+			return apperrors.ErrFieldPattern.With("pattern", p)
+		}
+	}
+	return nil
+}
+
+func compile(pattern string) (*regexp.Regexp, error) {
+	if re, ok := patterns.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q in proto rules: %w", pattern, err)
+	}
+	patterns.Store(pattern, re)
+	return re, nil
+}
@@ -0,0 +1,56 @@
+package validate
+
+import (
+	"slices"
+	"testing"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+)
+
+// fieldNames поля с нарушениями
+func fieldNames(err error) []string {
+	var names []string
+	for _, f := range apperrors.FieldErrors(err) {
+		names = append(names, f.Field)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestMessage(t *testing.T) {
+	task := &pb.Task{Date: "2024-01-26", Tags: []string{"home", "my home"}}
+	want := []string{"date", "tags[1]", "title"}
+	if got := fieldNames(Message(task)); !slices.Equal(got, want) {
+		t.Errorf("Message() fields = %v, want %v", got, want)
+	}
+	if err := Message(&pb.Task{Title: "t", Date: "20240126"}); err != nil {
+		t.Errorf("Message() error: %v", err)
+	}
+}
+
+func TestPartial(t *testing.T) {
+	req := &pb.UpdateTaskRequest{Task: &pb.Task{Id: 1, Date: "2024-01-26", Tags: []string{"my home"}}}
+
+	cases := []struct {
+		fields []string
+		want   []string
+	}{
+		// без списка полей — полная проверка
+		{nil, []string{"task.date", "task.tags[0]", "task.title"}},
+		{[]string{"id", "priority"}, nil},
+		{[]string{"tags"}, []string{"task.tags[0]"}},
+		{[]string{"title", "date"}, []string{"task.date", "task.title"}},
+	}
+	for _, tc := range cases {
+		req.Fields = tc.fields
+		if got := fieldNames(Partial(req, "task.", tc.fields)); !slices.Equal(got, tc.want) {
+			t.Errorf("Partial(%v) fields = %v, want %v", tc.fields, got, tc.want)
+		}
+	}
+
+	// сама задача обязательна при любом списке полей
+	if got := fieldNames(Partial(&pb.UpdateTaskRequest{}, "task.", []string{"title"})); !slices.Equal(got, []string{"task"}) {
+		t.Errorf("Partial() without task fields = %v, want [task]", got)
+	}
+}
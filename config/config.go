@@ -2,6 +2,7 @@ package common
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
 )
@@ -11,6 +12,18 @@ type Config struct {
 	DBServiceAddress string `envconfig:"DB_SERVICE_ADDRESS" required:"true"`
 	TodoPort         string `envconfig:"TODO_PORT" required:"true"`
 
+	// дедлайны вызовов db-сервиса: общий и по методам (GetTask:1s,ListTasks:3s)
+	RPCTimeout  time.Duration            `envconfig:"RPC_TIMEOUT" default:"5s"`
+	RPCTimeouts map[string]time.Duration `envconfig:"RPC_TIMEOUTS"`
+	// дедлайн потоков UploadAttachment и DownloadAttachment: 10 минут хватает
+	// на вложение ATTACHMENT_MAX_SIZE (25 МБ) при скорости от 45 КБ/с
+	RPCStreamTimeout time.Duration `envconfig:"RPC_STREAM_TIMEOUT" default:"10m"`
+	// число попыток для идемпотентных методов GetTask, ListTasks и NextDate, 1 — без повторов
+	RPCMaxAttempts int `envconfig:"RPC_MAX_ATTEMPTS" default:"3"`
+	// прокси с аутентификацией перед API (IP или CIDR через запятую): только от них
+	// принимаются заголовки X-Actor и X-Request-ID, у остальных клиентов они отбрасываются
+	TrustedProxies []string `envconfig:"TRUSTED_PROXIES"`
+
 	// DB сервис
 	DBHost     string `envconfig:"DB_HOST" required:"true"`
 	DBPort     string `envconfig:"DB_PORT" required:"true"`
@@ -21,7 +34,31 @@ type Config struct {
 
 	GRPCPort string `envconfig:"GRPC_PORT" required:"true"`
 
+	// период фоновой обработки пропущенных повторений, 0 — отключена
+	CatchUpInterval time.Duration `envconfig:"CATCH_UP_INTERVAL" default:"0"`
+
 	RedisAddr string `envconfig:"REDIS_ADDR" required:"true"`
+
+	// часовой пояс задач без явного tz (IANA), пусто — локальный пояс сервера
+	DefaultTZ string `envconfig:"DEFAULT_TZ" default:""`
+
+	// производственный календарь (.json или .ics) для правил wd и сдвига на рабочий день
+	CalendarPath string `envconfig:"CALENDAR_PATH" default:""`
+
+	// режим стенда: замороженное время db-сервиса (YYYYMMDD или RFC 3339) и токен админских методов
+	FrozenTime string `envconfig:"FROZEN_TIME" default:""`
+	AdminToken string `envconfig:"ADMIN_TOKEN" default:""`
+
+	// хранилище вложений: fs — каталог BLOB_DIR, s3 — бакет S3-совместимого хранилища (MinIO)
+	BlobStore   string `envconfig:"BLOB_STORE" default:"fs"`
+	BlobDir     string `envconfig:"BLOB_DIR" default:"attachments"`
+	S3Endpoint  string `envconfig:"S3_ENDPOINT" default:""`
+	S3Region    string `envconfig:"S3_REGION" default:"us-east-1"`
+	S3Bucket    string `envconfig:"S3_BUCKET" default:""`
+	S3AccessKey string `envconfig:"S3_ACCESS_KEY" default:""`
+	S3SecretKey string `envconfig:"S3_SECRET_KEY" default:""`
+	// наибольший размер вложения в байтах
+	AttachmentMaxSize int64 `envconfig:"ATTACHMENT_MAX_SIZE" default:"26214400"`
 }
 
 func NewConfig() (*Config, error) {
@@ -21,6 +21,119 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Priority приоритет задачи, больше — важнее
+type Priority int32
+
+const (
+	Priority_PRIORITY_UNSPECIFIED Priority = 0
+	Priority_PRIORITY_LOW         Priority = 1
+	Priority_PRIORITY_NORMAL      Priority = 2
+	Priority_PRIORITY_HIGH        Priority = 3
+	Priority_PRIORITY_URGENT      Priority = 4
+)
+
+// Enum value maps for Priority.
+var (
+	Priority_name = map[int32]string{
+		0: "PRIORITY_UNSPECIFIED",
+		1: "PRIORITY_LOW",
+		2: "PRIORITY_NORMAL",
+		3: "PRIORITY_HIGH",
+		4: "PRIORITY_URGENT",
+	}
+	Priority_value = map[string]int32{
+		"PRIORITY_UNSPECIFIED": 0,
+		"PRIORITY_LOW":         1,
+		"PRIORITY_NORMAL":      2,
+		"PRIORITY_HIGH":        3,
+		"PRIORITY_URGENT":      4,
+	}
+)
+
+func (x Priority) Enum() *Priority {
+	p := new(Priority)
+	*p = x
+	return p
+}
+
+func (x Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_proto_enumTypes[0].Descriptor()
+}
+
+func (Priority) Type() protoreflect.EnumType {
+	return &file_task_proto_enumTypes[0]
+}
+
+func (x Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Priority.Descriptor instead.
+func (Priority) EnumDescriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{0}
+}
+
+// Status статус задачи, выполнение статусом не является: DoneTask переносит или удаляет задачу
+type Status int32
+
+const (
+	Status_STATUS_UNSPECIFIED Status = 0
+	Status_STATUS_TODO        Status = 1
+	Status_STATUS_IN_PROGRESS Status = 2
+	Status_STATUS_WAITING     Status = 3
+	Status_STATUS_CANCELLED   Status = 4
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_TODO",
+		2: "STATUS_IN_PROGRESS",
+		3: "STATUS_WAITING",
+		4: "STATUS_CANCELLED",
+	}
+	Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_TODO":        1,
+		"STATUS_IN_PROGRESS": 2,
+		"STATUS_WAITING":     3,
+		"STATUS_CANCELLED":   4,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_task_proto_enumTypes[1].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_task_proto_enumTypes[1]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Status.Descriptor instead.
+func (Status) EnumDescriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{1}
+}
+
+// ограничения полей задачи соответствуют размерам колонок в Postgres
 type Task struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -28,6 +141,23 @@ type Task struct {
 	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
 	Comment       string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
 	Repeat        string                 `protobuf:"bytes,5,opt,name=repeat,proto3" json:"repeat,omitempty"`
+	Time          string                 `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`                                   // HH:MM, необязательно
+	Tz            string                 `protobuf:"bytes,7,opt,name=tz,proto3" json:"tz,omitempty"`                                       // IANA часовой пояс, например Europe/Moscow
+	Remaining     int32                  `protobuf:"varint,8,opt,name=remaining,proto3" json:"remaining,omitempty"`                        // оставшиеся повторения по count, 0 — без ограничения
+	RepeatMode    string                 `protobuf:"bytes,9,opt,name=repeat_mode,json=repeatMode,proto3" json:"repeat_mode,omitempty"`     // schedule — от даты по расписанию, completion — от дня выполнения
+	Anchor        string                 `protobuf:"bytes,10,opt,name=anchor,proto3" json:"anchor,omitempty"`                              // дата текущего повторения по расписанию, если оно отложено
+	Exceptions    []string               `protobuf:"bytes,11,rep,name=exceptions,proto3" json:"exceptions,omitempty"`                      // пропущенные даты серии
+	CatchUp       string                 `protobuf:"bytes,12,opt,name=catch_up,json=catchUp,proto3" json:"catch_up,omitempty"`             // skip, materialize или history для пропущенных повторений
+	Priority      Priority               `protobuf:"varint,13,opt,name=priority,proto3,enum=scheduler.Priority" json:"priority,omitempty"` // не заданный приоритет сохраняется как normal
+	Tags          []string               `protobuf:"bytes,14,rep,name=tags,proto3" json:"tags,omitempty"`                                  // имена меток, регистр не важен
+	ProjectId     int32                  `protobuf:"varint,15,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`      // 0 — задача без проекта
+	ParentId      int32                  `protobuf:"varint,16,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`         // 0 — задача верхнего уровня
+	Checklist     []*ChecklistItem       `protobuf:"bytes,17,rep,name=checklist,proto3" json:"checklist,omitempty"`
+	DependsOn     []int32                `protobuf:"varint,18,rep,packed,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"` // только для чтения, меняется через AddDependency и RemoveDependency
+	Blocked       bool                   `protobuf:"varint,19,opt,name=blocked,proto3" json:"blocked,omitempty"`                             // только для чтения: есть невыполненные задачи из depends_on
+	Status        Status                 `protobuf:"varint,20,opt,name=status,proto3,enum=scheduler.Status" json:"status,omitempty"`         // при создании, не заданный — todo; дальше меняется через SetStatus
+	CreatedAt     string                 `protobuf:"bytes,21,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`         // RFC 3339, только для чтения
+	UpdatedAt     string                 `protobuf:"bytes,22,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`         // RFC 3339, только для чтения
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -97,10 +227,134 @@ func (x *Task) GetRepeat() string {
 	return ""
 }
 
+func (x *Task) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *Task) GetTz() string {
+	if x != nil {
+		return x.Tz
+	}
+	return ""
+}
+
+func (x *Task) GetRemaining() int32 {
+	if x != nil {
+		return x.Remaining
+	}
+	return 0
+}
+
+func (x *Task) GetRepeatMode() string {
+	if x != nil {
+		return x.RepeatMode
+	}
+	return ""
+}
+
+func (x *Task) GetAnchor() string {
+	if x != nil {
+		return x.Anchor
+	}
+	return ""
+}
+
+func (x *Task) GetExceptions() []string {
+	if x != nil {
+		return x.Exceptions
+	}
+	return nil
+}
+
+func (x *Task) GetCatchUp() string {
+	if x != nil {
+		return x.CatchUp
+	}
+	return ""
+}
+
+func (x *Task) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+func (x *Task) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Task) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *Task) GetParentId() int32 {
+	if x != nil {
+		return x.ParentId
+	}
+	return 0
+}
+
+func (x *Task) GetChecklist() []*ChecklistItem {
+	if x != nil {
+		return x.Checklist
+	}
+	return nil
+}
+
+func (x *Task) GetDependsOn() []int32 {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *Task) GetBlocked() bool {
+	if x != nil {
+		return x.Blocked
+	}
+	return false
+}
+
+func (x *Task) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *Task) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Task) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
 type ListTasksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
 	Search        string                 `protobuf:"bytes,2,opt,name=search,proto3" json:"search,omitempty"`
+	MinPriority   Priority               `protobuf:"varint,3,opt,name=min_priority,json=minPriority,proto3,enum=scheduler.Priority" json:"min_priority,omitempty"` // только задачи не ниже приоритета, UNSPECIFIED — все
+	Order         string                 `protobuf:"bytes,4,opt,name=order,proto3" json:"order,omitempty"`                                                         // date (по умолчанию) или priority
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`                                                           // только задачи со всеми метками
+	ProjectId     int32                  `protobuf:"varint,6,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`                               // только задачи проекта, 0 — все
+	Statuses      []Status               `protobuf:"varint,7,rep,packed,name=statuses,proto3,enum=scheduler.Status" json:"statuses,omitempty"`                     // только задачи в любом из статусов, пустой — все
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -149,6 +403,41 @@ func (x *ListTasksRequest) GetSearch() string {
 	return ""
 }
 
+func (x *ListTasksRequest) GetMinPriority() Priority {
+	if x != nil {
+		return x.MinPriority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+func (x *ListTasksRequest) GetOrder() string {
+	if x != nil {
+		return x.Order
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *ListTasksRequest) GetStatuses() []Status {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
 type ListTasksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
@@ -237,9 +526,12 @@ func (x *GetTaskResponse) GetTask() *Task {
 	return nil
 }
 
+// UpdateTaskRequest меняет поля задачи с именами fields (как в JSON API), остальные
+// остаются прежними; пустой fields — поля старого API: date, title, comment и repeat
 type UpdateTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Fields        []string               `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -281,6 +573,13 @@ func (x *UpdateTaskRequest) GetTask() *Task {
 	return nil
 }
 
+func (x *UpdateTaskRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
 type IDRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -325,18 +624,126 @@ func (x *IDRequest) GetId() int32 {
 	return 0
 }
 
+// DoneTaskRequest выполняет задачу, заблокированную зависимостями — только с force
+type DoneTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Force         bool                   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DoneTaskRequest) Reset() {
+	*x = DoneTaskRequest{}
+	mi := &file_task_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DoneTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DoneTaskRequest) ProtoMessage() {}
+
+func (x *DoneTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DoneTaskRequest.ProtoReflect.Descriptor instead.
+func (*DoneTaskRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DoneTaskRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DoneTaskRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+// DoneTaskResponse задача после выполнения: перенесенная на следующее повторение
+// или, если finished, последнее состояние удаленной задачи
+type DoneTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	Finished      bool                   `protobuf:"varint,2,opt,name=finished,proto3" json:"finished,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DoneTaskResponse) Reset() {
+	*x = DoneTaskResponse{}
+	mi := &file_task_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DoneTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DoneTaskResponse) ProtoMessage() {}
+
+func (x *DoneTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DoneTaskResponse.ProtoReflect.Descriptor instead.
+func (*DoneTaskResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DoneTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *DoneTaskResponse) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
 type NextDateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CurrentDate   string                 `protobuf:"bytes,1,opt,name=current_date,json=currentDate,proto3" json:"current_date,omitempty"`
 	TaskDate      string                 `protobuf:"bytes,2,opt,name=task_date,json=taskDate,proto3" json:"task_date,omitempty"`
 	RepeatRule    string                 `protobuf:"bytes,3,opt,name=repeat_rule,json=repeatRule,proto3" json:"repeat_rule,omitempty"`
+	Tz            string                 `protobuf:"bytes,4,opt,name=tz,proto3" json:"tz,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *NextDateRequest) Reset() {
 	*x = NextDateRequest{}
-	mi := &file_task_proto_msgTypes[6]
+	mi := &file_task_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -348,7 +755,7 @@ func (x *NextDateRequest) String() string {
 func (*NextDateRequest) ProtoMessage() {}
 
 func (x *NextDateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[6]
+	mi := &file_task_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -361,7 +768,7 @@ func (x *NextDateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NextDateRequest.ProtoReflect.Descriptor instead.
 func (*NextDateRequest) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{6}
+	return file_task_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *NextDateRequest) GetCurrentDate() string {
@@ -385,6 +792,13 @@ func (x *NextDateRequest) GetRepeatRule() string {
 	return ""
 }
 
+func (x *NextDateRequest) GetTz() string {
+	if x != nil {
+		return x.Tz
+	}
+	return ""
+}
+
 type NextDateResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NextDate      string                 `protobuf:"bytes,1,opt,name=next_date,json=nextDate,proto3" json:"next_date,omitempty"`
@@ -394,7 +808,7 @@ type NextDateResponse struct {
 
 func (x *NextDateResponse) Reset() {
 	*x = NextDateResponse{}
-	mi := &file_task_proto_msgTypes[7]
+	mi := &file_task_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -406,7 +820,7 @@ func (x *NextDateResponse) String() string {
 func (*NextDateResponse) ProtoMessage() {}
 
 func (x *NextDateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[7]
+	mi := &file_task_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -419,7 +833,7 @@ func (x *NextDateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NextDateResponse.ProtoReflect.Descriptor instead.
 func (*NextDateResponse) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{7}
+	return file_task_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *NextDateResponse) GetNextDate() string {
@@ -438,7 +852,7 @@ type AddTaskResponse struct {
 
 func (x *AddTaskResponse) Reset() {
 	*x = AddTaskResponse{}
-	mi := &file_task_proto_msgTypes[8]
+	mi := &file_task_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -450,7 +864,7 @@ func (x *AddTaskResponse) String() string {
 func (*AddTaskResponse) ProtoMessage() {}
 
 func (x *AddTaskResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[8]
+	mi := &file_task_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -463,7 +877,7 @@ func (x *AddTaskResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddTaskResponse.ProtoReflect.Descriptor instead.
 func (*AddTaskResponse) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{8}
+	return file_task_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AddTaskResponse) GetId() int32 {
@@ -477,25 +891,1880 @@ type UpdateDateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	NextDate      string                 `protobuf:"bytes,2,opt,name=next_date,json=nextDate,proto3" json:"next_date,omitempty"`
+	Remaining     *int32                 `protobuf:"varint,3,opt,name=remaining,proto3,oneof" json:"remaining,omitempty"` // не задан — счетчик серии не меняется
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateDateRequest) Reset() {
 	*x = UpdateDateRequest{}
-	mi := &file_task_proto_msgTypes[9]
+	mi := &file_task_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateDateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateDateRequest) ProtoMessage() {}
+
+func (x *UpdateDateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateDateRequest.ProtoReflect.Descriptor instead.
+func (*UpdateDateRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateDateRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateDateRequest) GetNextDate() string {
+	if x != nil {
+		return x.NextDate
+	}
+	return ""
+}
+
+func (x *UpdateDateRequest) GetRemaining() int32 {
+	if x != nil && x.Remaining != nil {
+		return *x.Remaining
+	}
+	return 0
+}
+
+// SnoozeTaskRequest откладывает текущее повторение на days дней или на дату date
+type SnoozeTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Days          int32                  `protobuf:"varint,2,opt,name=days,proto3" json:"days,omitempty"`
+	Date          string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SnoozeTaskRequest) Reset() {
+	*x = SnoozeTaskRequest{}
+	mi := &file_task_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SnoozeTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnoozeTaskRequest) ProtoMessage() {}
+
+func (x *SnoozeTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnoozeTaskRequest.ProtoReflect.Descriptor instead.
+func (*SnoozeTaskRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SnoozeTaskRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SnoozeTaskRequest) GetDays() int32 {
+	if x != nil {
+		return x.Days
+	}
+	return 0
+}
+
+func (x *SnoozeTaskRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+// SkipOccurrenceRequest пропускает повторение: текущее, если date пустая, или будущее на дату date
+type SkipOccurrenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Date          string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SkipOccurrenceRequest) Reset() {
+	*x = SkipOccurrenceRequest{}
+	mi := &file_task_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SkipOccurrenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkipOccurrenceRequest) ProtoMessage() {}
+
+func (x *SkipOccurrenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkipOccurrenceRequest.ProtoReflect.Descriptor instead.
+func (*SkipOccurrenceRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SkipOccurrenceRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SkipOccurrenceRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+type MissedOccurrence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId        int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Date          string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	Title         string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC 3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MissedOccurrence) Reset() {
+	*x = MissedOccurrence{}
+	mi := &file_task_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MissedOccurrence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MissedOccurrence) ProtoMessage() {}
+
+func (x *MissedOccurrence) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MissedOccurrence.ProtoReflect.Descriptor instead.
+func (*MissedOccurrence) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MissedOccurrence) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MissedOccurrence) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *MissedOccurrence) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *MissedOccurrence) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *MissedOccurrence) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListMissedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Missed        []*MissedOccurrence    `protobuf:"bytes,1,rep,name=missed,proto3" json:"missed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMissedResponse) Reset() {
+	*x = ListMissedResponse{}
+	mi := &file_task_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMissedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMissedResponse) ProtoMessage() {}
+
+func (x *ListMissedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMissedResponse.ProtoReflect.Descriptor instead.
+func (*ListMissedResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListMissedResponse) GetMissed() []*MissedOccurrence {
+	if x != nil {
+		return x.Missed
+	}
+	return nil
+}
+
+// SetClockRequest замораживает часы на frozen_time (YYYYMMDD или RFC 3339), пустое — размораживает
+type SetClockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FrozenTime    string                 `protobuf:"bytes,1,opt,name=frozen_time,json=frozenTime,proto3" json:"frozen_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetClockRequest) Reset() {
+	*x = SetClockRequest{}
+	mi := &file_task_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetClockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetClockRequest) ProtoMessage() {}
+
+func (x *SetClockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetClockRequest.ProtoReflect.Descriptor instead.
+func (*SetClockRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SetClockRequest) GetFrozenTime() string {
+	if x != nil {
+		return x.FrozenTime
+	}
+	return ""
+}
+
+type ClockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Now           string                 `protobuf:"bytes,1,opt,name=now,proto3" json:"now,omitempty"` // RFC 3339
+	Frozen        bool                   `protobuf:"varint,2,opt,name=frozen,proto3" json:"frozen,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClockResponse) Reset() {
+	*x = ClockResponse{}
+	mi := &file_task_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClockResponse) ProtoMessage() {}
+
+func (x *ClockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClockResponse.ProtoReflect.Descriptor instead.
+func (*ClockResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ClockResponse) GetNow() string {
+	if x != nil {
+		return x.Now
+	}
+	return ""
+}
+
+func (x *ClockResponse) GetFrozen() bool {
+	if x != nil {
+		return x.Frozen
+	}
+	return false
+}
+
+type EmptyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmptyRequest) Reset() {
+	*x = EmptyRequest{}
+	mi := &file_task_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmptyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyRequest) ProtoMessage() {}
+
+func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmptyRequest.ProtoReflect.Descriptor instead.
+func (*EmptyRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{18}
+}
+
+type EmptyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmptyResponse) Reset() {
+	*x = EmptyResponse{}
+	mi := &file_task_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmptyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyResponse) ProtoMessage() {}
+
+func (x *EmptyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmptyResponse.ProtoReflect.Descriptor instead.
+func (*EmptyResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{19}
+}
+
+// Tag метка задачи, task_count — число задач с меткой
+type Tag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	TaskCount     int32                  `protobuf:"varint,3,opt,name=task_count,json=taskCount,proto3" json:"task_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_task_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Tag) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tag) GetTaskCount() int32 {
+	if x != nil {
+		return x.TaskCount
+	}
+	return 0
+}
+
+type ListTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []*Tag                 `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_task_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTagsResponse) ProtoMessage() {}
+
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListTagsResponse) GetTags() []*Tag {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type CreateTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTagRequest) Reset() {
+	*x = CreateTagRequest{}
+	mi := &file_task_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTagRequest) ProtoMessage() {}
+
+func (x *CreateTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTagRequest.ProtoReflect.Descriptor instead.
+func (*CreateTagRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateTagRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// RenameTagRequest переименовывает метку, занятое имя — ошибка, такие метки объединяются через MergeTags
+type RenameTagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameTagRequest) Reset() {
+	*x = RenameTagRequest{}
+	mi := &file_task_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameTagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameTagRequest) ProtoMessage() {}
+
+func (x *RenameTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameTagRequest.ProtoReflect.Descriptor instead.
+func (*RenameTagRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RenameTagRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RenameTagRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// MergeTagsRequest переносит задачи меток source_ids на метку target_id и удаляет исходные
+type MergeTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourceIds     []int32                `protobuf:"varint,1,rep,packed,name=source_ids,json=sourceIds,proto3" json:"source_ids,omitempty"`
+	TargetId      int32                  `protobuf:"varint,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeTagsRequest) Reset() {
+	*x = MergeTagsRequest{}
+	mi := &file_task_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeTagsRequest) ProtoMessage() {}
+
+func (x *MergeTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeTagsRequest.ProtoReflect.Descriptor instead.
+func (*MergeTagsRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MergeTagsRequest) GetSourceIds() []int32 {
+	if x != nil {
+		return x.SourceIds
+	}
+	return nil
+}
+
+func (x *MergeTagsRequest) GetTargetId() int32 {
+	if x != nil {
+		return x.TargetId
+	}
+	return 0
+}
+
+// Project список задач, архивный проект только для чтения
+type Project struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Archived      bool                   `protobuf:"varint,4,opt,name=archived,proto3" json:"archived,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC 3339
+	TaskCount     int32                  `protobuf:"varint,6,opt,name=task_count,json=taskCount,proto3" json:"task_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	mi := &file_task_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Project) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Project) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Project) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Project) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Project) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Project) GetTaskCount() int32 {
+	if x != nil {
+		return x.TaskCount
+	}
+	return 0
+}
+
+type ListProjectsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IncludeArchived bool                   `protobuf:"varint,1,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_task_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListProjectsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+type ListProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsResponse) Reset() {
+	*x = ListProjectsResponse{}
+	mi := &file_task_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsResponse) ProtoMessage() {}
+
+func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+type ArchiveProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Archived      bool                   `protobuf:"varint,2,opt,name=archived,proto3" json:"archived,omitempty"` // false — вернуть из архива
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveProjectRequest) Reset() {
+	*x = ArchiveProjectRequest{}
+	mi := &file_task_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveProjectRequest) ProtoMessage() {}
+
+func (x *ArchiveProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveProjectRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveProjectRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ArchiveProjectRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ArchiveProjectRequest) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+// ProjectStats сводка по задачам проекта на сегодня по часам сервиса.
+// Отмененные задачи входят только в total и cancelled.
+type ProjectStats struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int32                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Overdue       int32                  `protobuf:"varint,3,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	DueToday      int32                  `protobuf:"varint,4,opt,name=due_today,json=dueToday,proto3" json:"due_today,omitempty"`
+	Upcoming      int32                  `protobuf:"varint,5,opt,name=upcoming,proto3" json:"upcoming,omitempty"`
+	HighPriority  int32                  `protobuf:"varint,6,opt,name=high_priority,json=highPriority,proto3" json:"high_priority,omitempty"` // high и urgent
+	Repeating     int32                  `protobuf:"varint,7,opt,name=repeating,proto3" json:"repeating,omitempty"`
+	Cancelled     int32                  `protobuf:"varint,8,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectStats) Reset() {
+	*x = ProjectStats{}
+	mi := &file_task_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectStats) ProtoMessage() {}
+
+func (x *ProjectStats) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectStats.ProtoReflect.Descriptor instead.
+func (*ProjectStats) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ProjectStats) GetProjectId() int32 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *ProjectStats) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ProjectStats) GetOverdue() int32 {
+	if x != nil {
+		return x.Overdue
+	}
+	return 0
+}
+
+func (x *ProjectStats) GetDueToday() int32 {
+	if x != nil {
+		return x.DueToday
+	}
+	return 0
+}
+
+func (x *ProjectStats) GetUpcoming() int32 {
+	if x != nil {
+		return x.Upcoming
+	}
+	return 0
+}
+
+func (x *ProjectStats) GetHighPriority() int32 {
+	if x != nil {
+		return x.HighPriority
+	}
+	return 0
+}
+
+func (x *ProjectStats) GetRepeating() int32 {
+	if x != nil {
+		return x.Repeating
+	}
+	return 0
+}
+
+func (x *ProjectStats) GetCancelled() int32 {
+	if x != nil {
+		return x.Cancelled
+	}
+	return 0
+}
+
+// ChecklistItem пункт чек-листа задачи
+type ChecklistItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Done          bool                   `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChecklistItem) Reset() {
+	*x = ChecklistItem{}
+	mi := &file_task_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChecklistItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChecklistItem) ProtoMessage() {}
+
+func (x *ChecklistItem) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChecklistItem.ProtoReflect.Descriptor instead.
+func (*ChecklistItem) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ChecklistItem) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ChecklistItem) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+// CheckItemRequest отмечает пункт index (с нуля) чек-листа задачи
+type CheckItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Index         int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Done          bool                   `protobuf:"varint,3,opt,name=done,proto3" json:"done,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckItemRequest) Reset() {
+	*x = CheckItemRequest{}
+	mi := &file_task_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckItemRequest) ProtoMessage() {}
+
+func (x *CheckItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckItemRequest.ProtoReflect.Descriptor instead.
+func (*CheckItemRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CheckItemRequest) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *CheckItemRequest) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *CheckItemRequest) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+// DependencyRequest ребро графа зависимостей: task_id ждет выполнения depends_on_id
+type DependencyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	DependsOnId   int32                  `protobuf:"varint,2,opt,name=depends_on_id,json=dependsOnId,proto3" json:"depends_on_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DependencyRequest) Reset() {
+	*x = DependencyRequest{}
+	mi := &file_task_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DependencyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DependencyRequest) ProtoMessage() {}
+
+func (x *DependencyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DependencyRequest.ProtoReflect.Descriptor instead.
+func (*DependencyRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DependencyRequest) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *DependencyRequest) GetDependsOnId() int32 {
+	if x != nil {
+		return x.DependsOnId
+	}
+	return 0
+}
+
+// SetStatusRequest переводит задачу в статус status, недопустимый переход — ошибка
+type SetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        Status                 `protobuf:"varint,2,opt,name=status,proto3,enum=scheduler.Status" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetStatusRequest) Reset() {
+	*x = SetStatusRequest{}
+	mi := &file_task_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetStatusRequest) ProtoMessage() {}
+
+func (x *SetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SetStatusRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *SetStatusRequest) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+// StatusChange переход задачи между статусами
+type StatusChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId        int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	From          Status                 `protobuf:"varint,3,opt,name=from,proto3,enum=scheduler.Status" json:"from,omitempty"`
+	To            Status                 `protobuf:"varint,4,opt,name=to,proto3,enum=scheduler.Status" json:"to,omitempty"`
+	ChangedAt     string                 `protobuf:"bytes,5,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"` // RFC 3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusChange) Reset() {
+	*x = StatusChange{}
+	mi := &file_task_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusChange) ProtoMessage() {}
+
+func (x *StatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusChange.ProtoReflect.Descriptor instead.
+func (*StatusChange) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *StatusChange) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StatusChange) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *StatusChange) GetFrom() Status {
+	if x != nil {
+		return x.From
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *StatusChange) GetTo() Status {
+	if x != nil {
+		return x.To
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *StatusChange) GetChangedAt() string {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return ""
+}
+
+type ListStatusChangesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Changes       []*StatusChange        `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStatusChangesResponse) Reset() {
+	*x = ListStatusChangesResponse{}
+	mi := &file_task_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStatusChangesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStatusChangesResponse) ProtoMessage() {}
+
+func (x *ListStatusChangesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStatusChangesResponse.ProtoReflect.Descriptor instead.
+func (*ListStatusChangesResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListStatusChangesResponse) GetChanges() []*StatusChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+// RevisionRequest версия number задачи task_id
+type RevisionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Number        int32                  `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevisionRequest) Reset() {
+	*x = RevisionRequest{}
+	mi := &file_task_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevisionRequest) ProtoMessage() {}
+
+func (x *RevisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevisionRequest.ProtoReflect.Descriptor instead.
+func (*RevisionRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RevisionRequest) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *RevisionRequest) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+// FieldChange отличие поля между версиями, значения в JSON
+type FieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Old           string                 `protobuf:"bytes,2,opt,name=old,proto3" json:"old,omitempty"`
+	New           string                 `protobuf:"bytes,3,opt,name=new,proto3" json:"new,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_task_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetOld() string {
+	if x != nil {
+		return x.Old
+	}
+	return ""
+}
+
+func (x *FieldChange) GetNew() string {
+	if x != nil {
+		return x.New
+	}
+	return ""
+}
+
+// TaskRevision прежняя версия задачи, changes — чем следующая версия отличается от нее
+type TaskRevision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId        int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Number        int32                  `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`
+	Snapshot      *Task                  `protobuf:"bytes,4,opt,name=snapshot,proto3" json:"snapshot,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC 3339
+	Changes       []*FieldChange         `protobuf:"bytes,6,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskRevision) Reset() {
+	*x = TaskRevision{}
+	mi := &file_task_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskRevision) ProtoMessage() {}
+
+func (x *TaskRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskRevision.ProtoReflect.Descriptor instead.
+func (*TaskRevision) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *TaskRevision) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TaskRevision) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TaskRevision) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *TaskRevision) GetSnapshot() *Task {
+	if x != nil {
+		return x.Snapshot
+	}
+	return nil
+}
+
+func (x *TaskRevision) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *TaskRevision) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type ListRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*TaskRevision        `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRevisionsResponse) Reset() {
+	*x = ListRevisionsResponse{}
+	mi := &file_task_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRevisionsResponse) ProtoMessage() {}
+
+func (x *ListRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListRevisionsResponse) GetRevisions() []*TaskRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+// ListAuditEventsRequest фильтр журнала аудита, пустые поля не ограничивают выборку.
+// Записи идут по возрастанию id, следующая страница — after_id последней записи.
+type ListAuditEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	Actor         string                 `protobuf:"bytes,3,opt,name=actor,proto3" json:"actor,omitempty"`
+	RequestId     string                 `protobuf:"bytes,4,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Since         string                 `protobuf:"bytes,5,opt,name=since,proto3" json:"since,omitempty"` // RFC 3339, включительно
+	Until         string                 `protobuf:"bytes,6,opt,name=until,proto3" json:"until,omitempty"` // RFC 3339, не включительно
+	AfterId       int32                  `protobuf:"varint,7,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,8,opt,name=limit,proto3" json:"limit,omitempty"` // 0 — 100, не больше 1000
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_task_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAuditEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAuditEventsRequest) ProtoMessage() {}
+
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListAuditEventsRequest) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *ListAuditEventsRequest) GetAfterId() int32 {
+	if x != nil {
+		return x.AfterId
+	}
+	return 0
+}
+
+func (x *ListAuditEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// AuditEvent запись журнала: кто, откуда и как изменил задачу
+type AuditEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"`
+	TaskId        int32                  `protobuf:"varint,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Actor         string                 `protobuf:"bytes,4,opt,name=actor,proto3" json:"actor,omitempty"`
+	ClientAddr    string                 `protobuf:"bytes,5,opt,name=client_addr,json=clientAddr,proto3" json:"client_addr,omitempty"` // адрес HTTP-клиента API
+	SourceAddr    string                 `protobuf:"bytes,6,opt,name=source_addr,json=sourceAddr,proto3" json:"source_addr,omitempty"` // адрес реплики API
+	RequestId     string                 `protobuf:"bytes,7,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	Before        string                 `protobuf:"bytes,8,opt,name=before,proto3" json:"before,omitempty"`                         // задача в JSON до изменения, пустая — задачи не было
+	After         string                 `protobuf:"bytes,9,opt,name=after,proto3" json:"after,omitempty"`                           // задача в JSON после изменения, пустая — задача удалена
+	CreatedAt     string                 `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC 3339 с долями секунды
+	Detail        string                 `protobuf:"bytes,11,opt,name=detail,proto3" json:"detail,omitempty"`                        // JSON того, что изменилось вне задачи: вложение, имя метки; пустая — нет
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_task_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AuditEvent) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AuditEvent) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetClientAddr() string {
+	if x != nil {
+		return x.ClientAddr
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetSourceAddr() string {
+	if x != nil {
+		return x.SourceAddr
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type ListAuditEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_task_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateDateRequest) String() string {
+func (x *ListAuditEventsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateDateRequest) ProtoMessage() {}
+func (*ListAuditEventsResponse) ProtoMessage() {}
 
-func (x *UpdateDateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[9]
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -506,46 +2775,133 @@ func (x *UpdateDateRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateDateRequest.ProtoReflect.Descriptor instead.
-func (*UpdateDateRequest) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *UpdateDateRequest) GetId() int32 {
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+// Attachment файл задачи, содержимое лежит в хранилище вложений
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId        int32                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,4,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`                           // байт
+	Sha256        string                 `protobuf:"bytes,6,opt,name=sha256,proto3" json:"sha256,omitempty"`                        // hex
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // RFC 3339
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_task_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Attachment) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *UpdateDateRequest) GetNextDate() string {
+func (x *Attachment) GetTaskId() int32 {
 	if x != nil {
-		return x.NextDate
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *Attachment) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
 
-type EmptyResponse struct {
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Attachment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type ListAttachmentsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachments   []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EmptyResponse) Reset() {
-	*x = EmptyResponse{}
-	mi := &file_task_proto_msgTypes[10]
+func (x *ListAttachmentsResponse) Reset() {
+	*x = ListAttachmentsResponse{}
+	mi := &file_task_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EmptyResponse) String() string {
+func (x *ListAttachmentsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmptyResponse) ProtoMessage() {}
+func (*ListAttachmentsResponse) ProtoMessage() {}
 
-func (x *EmptyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_task_proto_msgTypes[10]
+func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -556,59 +2912,548 @@ func (x *EmptyResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmptyResponse.ProtoReflect.Descriptor instead.
-func (*EmptyResponse) Descriptor() ([]byte, []int) {
-	return file_task_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ListAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListAttachmentsResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+// AttachmentInfo описание загружаемого файла, пустой content_type — application/octet-stream
+type AttachmentInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int32                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentInfo) Reset() {
+	*x = AttachmentInfo{}
+	mi := &file_task_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentInfo) ProtoMessage() {}
+
+func (x *AttachmentInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentInfo.ProtoReflect.Descriptor instead.
+func (*AttachmentInfo) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *AttachmentInfo) GetTaskId() int32 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AttachmentInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AttachmentInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+// UploadAttachmentRequest сообщение потока загрузки: сначала info, дальше части содержимого
+type UploadAttachmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*UploadAttachmentRequest_Info
+	//	*UploadAttachmentRequest_Chunk
+	Data          isUploadAttachmentRequest_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadAttachmentRequest) Reset() {
+	*x = UploadAttachmentRequest{}
+	mi := &file_task_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadAttachmentRequest) ProtoMessage() {}
+
+func (x *UploadAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*UploadAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UploadAttachmentRequest) GetData() isUploadAttachmentRequest_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetInfo() *AttachmentInfo {
+	if x != nil {
+		if x, ok := x.Data.(*UploadAttachmentRequest_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *UploadAttachmentRequest) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*UploadAttachmentRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isUploadAttachmentRequest_Data interface {
+	isUploadAttachmentRequest_Data()
+}
+
+type UploadAttachmentRequest_Info struct {
+	Info *AttachmentInfo `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type UploadAttachmentRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadAttachmentRequest_Info) isUploadAttachmentRequest_Data() {}
+
+func (*UploadAttachmentRequest_Chunk) isUploadAttachmentRequest_Data() {}
+
+// DownloadAttachmentResponse сообщение потока выгрузки: сначала info, дальше части содержимого
+type DownloadAttachmentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*DownloadAttachmentResponse_Info
+	//	*DownloadAttachmentResponse_Chunk
+	Data          isDownloadAttachmentResponse_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadAttachmentResponse) Reset() {
+	*x = DownloadAttachmentResponse{}
+	mi := &file_task_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadAttachmentResponse) ProtoMessage() {}
+
+func (x *DownloadAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_task_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*DownloadAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_task_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DownloadAttachmentResponse) GetData() isDownloadAttachmentResponse_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetInfo() *Attachment {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadAttachmentResponse_Info); ok {
+			return x.Info
+		}
+	}
+	return nil
+}
+
+func (x *DownloadAttachmentResponse) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadAttachmentResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isDownloadAttachmentResponse_Data interface {
+	isDownloadAttachmentResponse_Data()
+}
+
+type DownloadAttachmentResponse_Info struct {
+	Info *Attachment `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type DownloadAttachmentResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
 }
 
+func (*DownloadAttachmentResponse_Info) isDownloadAttachmentResponse_Data() {}
+
+func (*DownloadAttachmentResponse_Chunk) isDownloadAttachmentResponse_Data() {}
+
 var File_task_proto protoreflect.FileDescriptor
 
 const file_task_proto_rawDesc = "" +
 	"\n" +
 	"\n" +
-	"task.proto\x12\tscheduler\"r\n" +
+	"task.proto\x12\tscheduler\x1a\x0evalidate.proto\"\xb1\x06\n" +
 	"\x04Task\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04date\x18\x02 \x01(\tR\x04date\x12\x14\n" +
-	"\x05title\x18\x03 \x01(\tR\x05title\x12\x18\n" +
-	"\acomment\x18\x04 \x01(\tR\acomment\x12\x16\n" +
-	"\x06repeat\x18\x05 \x01(\tR\x06repeat\"@\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12$\n" +
+	"\x04date\x18\x02 \x01(\tB\x10\xa2\xbb\x18\f\x1a\n" +
+	"^[0-9]{8}$R\x04date\x12\x1f\n" +
+	"\x05title\x18\x03 \x01(\tB\t\xa2\xbb\x18\x05\b\x01\x10\xff\x01R\x05title\x12!\n" +
+	"\acomment\x18\x04 \x01(\tB\a\xa2\xbb\x18\x03\x10\x80 R\acomment\x12\x1f\n" +
+	"\x06repeat\x18\x05 \x01(\tB\a\xa2\xbb\x18\x03\x10\x80\x01R\x06repeat\x129\n" +
+	"\x04time\x18\x06 \x01(\tB%\xa2\xbb\x18!\x1a\x1f^([01][0-9]|2[0-3]):[0-5][0-9]$R\x04time\x12\x16\n" +
+	"\x02tz\x18\a \x01(\tB\x06\xa2\xbb\x18\x02\x10@R\x02tz\x12\x1c\n" +
+	"\tremaining\x18\b \x01(\x05R\tremaining\x12'\n" +
+	"\vrepeat_mode\x18\t \x01(\tB\x06\xa2\xbb\x18\x02\x10\x10R\n" +
+	"repeatMode\x12(\n" +
+	"\x06anchor\x18\n" +
+	" \x01(\tB\x10\xa2\xbb\x18\f\x1a\n" +
+	"^[0-9]{8}$R\x06anchor\x120\n" +
+	"\n" +
+	"exceptions\x18\v \x03(\tB\x10\xa2\xbb\x18\f\x1a\n" +
+	"^[0-9]{8}$R\n" +
+	"exceptions\x12!\n" +
+	"\bcatch_up\x18\f \x01(\tB\x06\xa2\xbb\x18\x02\x10\x10R\acatchUp\x12/\n" +
+	"\bpriority\x18\r \x01(\x0e2\x13.scheduler.PriorityR\bpriority\x12.\n" +
+	"\x04tags\x18\x0e \x03(\tB\x1a\xa2\xbb\x18\x16\x10@\x1a\x12^[\\p{L}\\p{N}_.-]+$R\x04tags\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x0f \x01(\x05R\tprojectId\x12\x1b\n" +
+	"\tparent_id\x18\x10 \x01(\x05R\bparentId\x126\n" +
+	"\tchecklist\x18\x11 \x03(\v2\x18.scheduler.ChecklistItemR\tchecklist\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x12 \x03(\x05R\tdependsOn\x12\x18\n" +
+	"\ablocked\x18\x13 \x01(\bR\ablocked\x12)\n" +
+	"\x06status\x18\x14 \x01(\x0e2\x11.scheduler.StatusR\x06status\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x15 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x16 \x01(\tR\tupdatedAt\"\x89\x02\n" +
 	"\x10ListTasksRequest\x12\x14\n" +
-	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06search\x18\x02 \x01(\tR\x06search\":\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\x06search\x18\x02 \x01(\tB\a\xa2\xbb\x18\x03\x10\xff\x01R\x06search\x126\n" +
+	"\fmin_priority\x18\x03 \x01(\x0e2\x13.scheduler.PriorityR\vminPriority\x12\x1c\n" +
+	"\x05order\x18\x04 \x01(\tB\x06\xa2\xbb\x18\x02\x10\x10R\x05order\x12\x1a\n" +
+	"\x04tags\x18\x05 \x03(\tB\x06\xa2\xbb\x18\x02\x10@R\x04tags\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x06 \x01(\x05R\tprojectId\x12-\n" +
+	"\bstatuses\x18\a \x03(\x0e2\x11.scheduler.StatusR\bstatuses\":\n" +
 	"\x11ListTasksResponse\x12%\n" +
 	"\x05tasks\x18\x01 \x03(\v2\x0f.scheduler.TaskR\x05tasks\"6\n" +
 	"\x0fGetTaskResponse\x12#\n" +
-	"\x04task\x18\x01 \x01(\v2\x0f.scheduler.TaskR\x04task\"8\n" +
-	"\x11UpdateTaskRequest\x12#\n" +
-	"\x04task\x18\x01 \x01(\v2\x0f.scheduler.TaskR\x04task\"\x1b\n" +
-	"\tIDRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"r\n" +
-	"\x0fNextDateRequest\x12!\n" +
-	"\fcurrent_date\x18\x01 \x01(\tR\vcurrentDate\x12\x1b\n" +
-	"\ttask_date\x18\x02 \x01(\tR\btaskDate\x12\x1f\n" +
-	"\vrepeat_rule\x18\x03 \x01(\tR\n" +
-	"repeatRule\"/\n" +
+	"\x04task\x18\x01 \x01(\v2\x0f.scheduler.TaskR\x04task\"`\n" +
+	"\x11UpdateTaskRequest\x12+\n" +
+	"\x04task\x18\x01 \x01(\v2\x0f.scheduler.TaskB\x06\xa2\xbb\x18\x02\b\x01R\x04task\x12\x1e\n" +
+	"\x06fields\x18\x02 \x03(\tB\x06\xa2\xbb\x18\x02\x10 R\x06fields\"#\n" +
+	"\tIDRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\x05B\x06\xa2\xbb\x18\x02\b\x01R\x02id\"?\n" +
+	"\x0fDoneTaskRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\x05B\x06\xa2\xbb\x18\x02\b\x01R\x02id\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"S\n" +
+	"\x10DoneTaskResponse\x12#\n" +
+	"\x04task\x18\x01 \x01(\v2\x0f.scheduler.TaskR\x04task\x12\x1a\n" +
+	"\bfinished\x18\x02 \x01(\bR\bfinished\"\xb9\x01\n" +
+	"\x0fNextDateRequest\x123\n" +
+	"\fcurrent_date\x18\x01 \x01(\tB\x10\xa2\xbb\x18\f\x1a\n" +
+	"^[0-9]{8}$R\vcurrentDate\x12/\n" +
+	"\ttask_date\x18\x02 \x01(\tB\x12\xa2\xbb\x18\x0e\b\x01\x1a\n" +
+	"^[0-9]{8}$R\btaskDate\x12(\n" +
+	"\vrepeat_rule\x18\x03 \x01(\tB\a\xa2\xbb\x18\x03\x10\x80\x01R\n" +
+	"repeatRule\x12\x16\n" +
+	"\x02tz\x18\x04 \x01(\tB\x06\xa2\xbb\x18\x02\x10@R\x02tz\"/\n" +
 	"\x10NextDateResponse\x12\x1b\n" +
 	"\tnext_date\x18\x01 \x01(\tR\bnextDate\"!\n" +
 	"\x0fAddTaskResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"@\n" +
-	"\x11UpdateDateRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1b\n" +
-	"\tnext_date\x18\x02 \x01(\tR\bnextDate\"\x0f\n" +
-	"\rEmptyResponse2\x9a\x04\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\"\x8d\x01\n" +
+	"\x11UpdateDateRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\x05B\x06\xa2\xbb\x18\x02\b\x01R\x02id\x12/\n" +
+	"\tnext_date\x18\x02 \x01(\tB\x12\xa2\xbb\x18\x0e\b\x01\x1a\n" +
+	"^[0-9]{8}$R\bnextDate\x12!\n" +
+	"\tremaining\x18\x03 \x01(\x05H\x00R\tremaining\x88\x01\x01B\f\n" +
+	"\n" +
+	"_remaining\"e\n" +
+	"\x11SnoozeTaskRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\x05B\x06\xa2\xbb\x18\x02\b\x01R\x02id\x12\x12\n" +
+	"\x04days\x18\x02 \x01(\x05R\x04days\x12$\n" +
+	"\x04date\x18\x03 \x01(\tB\x10\xa2\xbb\x18\f\x1a\n" +
+	"^[0-9]{8}$R\x04date\"U\n" +
+	"\x15SkipOccurrenceRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\x05B\x06\xa2\xbb\x18\x02\b\x01R\x02id\x12$\n" +
+	"\x04date\x18\x02 \x01(\tB\x10\xa2\xbb\x18\f\x1a\n" +
+	"^[0-9]{8}$R\x04date\"\x84\x01\n" +
+	"\x10MissedOccurrence\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x05R\x06taskId\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\"I\n" +
+	"\x12ListMissedResponse\x123\n" +
+	"\x06missed\x18\x01 \x03(\v2\x1b.scheduler.MissedOccurrenceR\x06missed\":\n" +
+	"\x0fSetClockRequest\x12'\n" +
+	"\vfrozen_time\x18\x01 \x01(\tB\x06\xa2\xbb\x18\x02\x10@R\n" +
+	"frozenTime\"9\n" +
+	"\rClockResponse\x12\x10\n" +
+	"\x03now\x18\x01 \x01(\tR\x03now\x12\x16\n" +
+	"\x06frozen\x18\x02 \x01(\bR\x06frozen\"\x0e\n" +
+	"\fEmptyRequest\"\x0f\n" +
+	"\rEmptyResponse\"H\n" +
+	"\x03Tag\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"task_count\x18\x03 \x01(\x05R\ttaskCount\"6\n" +
+	"\x10ListTagsResponse\x12\"\n" +
+	"\x04tags\x18\x01 \x03(\v2\x0e.scheduler.TagR\x04tags\"D\n" +
+	"\x10CreateTagRequest\x120\n" +
+	"\x04name\x18\x01 \x01(\tB\x1c\xa2\xbb\x18\x18\b\x01\x10@\x1a\x12^[\\p{L}\\p{N}_.-]+$R\x04name\"\\\n" +
+	"\x10RenameTagRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\x05B\x06\xa2\xbb\x18\x02\b\x01R\x02id\x120\n" +
+	"\x04name\x18\x02 \x01(\tB\x1c\xa2\xbb\x18\x18\b\x01\x10@\x1a\x12^[\\p{L}\\p{N}_.-]+$R\x04name\"^\n" +
+	"\x10MergeTagsRequest\x12%\n" +
+	"\n" +
+	"source_ids\x18\x01 \x03(\x05B\x06\xa2\xbb\x18\x02\b\x01R\tsourceIds\x12#\n" +
+	"\ttarget_id\x18\x02 \x01(\x05B\x06\xa2\xbb\x18\x02\b\x01R\btargetId\"\xbd\x01\n" +
+	"\aProject\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1d\n" +
+	"\x04name\x18\x02 \x01(\tB\t\xa2\xbb\x18\x05\b\x01\x10\xff\x01R\x04name\x12)\n" +
+	"\vdescription\x18\x03 \x01(\tB\a\xa2\xbb\x18\x03\x10\x80 R\vdescription\x12\x1a\n" +
+	"\barchived\x18\x04 \x01(\bR\barchived\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"task_count\x18\x06 \x01(\x05R\ttaskCount\"@\n" +
+	"\x13ListProjectsRequest\x12)\n" +
+	"\x10include_archived\x18\x01 \x01(\bR\x0fincludeArchived\"F\n" +
+	"\x14ListProjectsResponse\x12.\n" +
+	"\bprojects\x18\x01 \x03(\v2\x12.scheduler.ProjectR\bprojects\"K\n" +
+	"\x15ArchiveProjectRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\x05B\x06\xa2\xbb\x18\x02\b\x01R\x02id\x12\x1a\n" +
+	"\barchived\x18\x02 \x01(\bR\barchived\"\xf7\x01\n" +
+	"\fProjectStats\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x05R\tprojectId\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x18\n" +
+	"\aoverdue\x18\x03 \x01(\x05R\aoverdue\x12\x1b\n" +
+	"\tdue_today\x18\x04 \x01(\x05R\bdueToday\x12\x1a\n" +
+	"\bupcoming\x18\x05 \x01(\x05R\bupcoming\x12#\n" +
+	"\rhigh_priority\x18\x06 \x01(\x05R\fhighPriority\x12\x1c\n" +
+	"\trepeating\x18\a \x01(\x05R\trepeating\x12\x1c\n" +
+	"\tcancelled\x18\b \x01(\x05R\tcancelled\"D\n" +
+	"\rChecklistItem\x12\x1f\n" +
+	"\x05title\x18\x01 \x01(\tB\t\xa2\xbb\x18\x05\b\x01\x10\xff\x01R\x05title\x12\x12\n" +
+	"\x04done\x18\x02 \x01(\bR\x04done\"]\n" +
+	"\x10CheckItemRequest\x12\x1f\n" +
+	"\atask_id\x18\x01 \x01(\x05B\x06\xa2\xbb\x18\x02\b\x01R\x06taskId\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04done\x18\x03 \x01(\bR\x04done\"`\n" +
+	"\x11DependencyRequest\x12\x1f\n" +
+	"\atask_id\x18\x01 \x01(\x05B\x06\xa2\xbb\x18\x02\b\x01R\x06taskId\x12*\n" +
+	"\rdepends_on_id\x18\x02 \x01(\x05B\x06\xa2\xbb\x18\x02\b\x01R\vdependsOnId\"U\n" +
+	"\x10SetStatusRequest\x12\x16\n" +
+	"\x02id\x18\x01 \x01(\x05B\x06\xa2\xbb\x18\x02\b\x01R\x02id\x12)\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x11.scheduler.StatusR\x06status\"\xa0\x01\n" +
+	"\fStatusChange\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x05R\x06taskId\x12%\n" +
+	"\x04from\x18\x03 \x01(\x0e2\x11.scheduler.StatusR\x04from\x12!\n" +
+	"\x02to\x18\x04 \x01(\x0e2\x11.scheduler.StatusR\x02to\x12\x1d\n" +
+	"\n" +
+	"changed_at\x18\x05 \x01(\tR\tchangedAt\"N\n" +
+	"\x19ListStatusChangesResponse\x121\n" +
+	"\achanges\x18\x01 \x03(\v2\x17.scheduler.StatusChangeR\achanges\"R\n" +
+	"\x0fRevisionRequest\x12\x1f\n" +
+	"\atask_id\x18\x01 \x01(\x05B\x06\xa2\xbb\x18\x02\b\x01R\x06taskId\x12\x1e\n" +
+	"\x06number\x18\x02 \x01(\x05B\x06\xa2\xbb\x18\x02\b\x01R\x06number\"G\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x10\n" +
+	"\x03old\x18\x02 \x01(\tR\x03old\x12\x10\n" +
+	"\x03new\x18\x03 \x01(\tR\x03new\"\xcd\x01\n" +
+	"\fTaskRevision\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x05R\x06taskId\x12\x16\n" +
+	"\x06number\x18\x03 \x01(\x05R\x06number\x12+\n" +
+	"\bsnapshot\x18\x04 \x01(\v2\x0f.scheduler.TaskR\bsnapshot\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\tR\tcreatedAt\x120\n" +
+	"\achanges\x18\x06 \x03(\v2\x16.scheduler.FieldChangeR\achanges\"N\n" +
+	"\x15ListRevisionsResponse\x125\n" +
+	"\trevisions\x18\x01 \x03(\v2\x17.scheduler.TaskRevisionR\trevisions\"\x84\x02\n" +
+	"\x16ListAuditEventsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x05R\x06taskId\x12\x1e\n" +
+	"\x06action\x18\x02 \x01(\tB\x06\xa2\xbb\x18\x02\x10 R\x06action\x12\x1d\n" +
+	"\x05actor\x18\x03 \x01(\tB\a\xa2\xbb\x18\x03\x10\xff\x01R\x05actor\x12%\n" +
+	"\n" +
+	"request_id\x18\x04 \x01(\tB\x06\xa2\xbb\x18\x02\x10@R\trequestId\x12\x1c\n" +
+	"\x05since\x18\x05 \x01(\tB\x06\xa2\xbb\x18\x02\x10@R\x05since\x12\x1c\n" +
+	"\x05until\x18\x06 \x01(\tB\x06\xa2\xbb\x18\x02\x10@R\x05until\x12\x19\n" +
+	"\bafter_id\x18\a \x01(\x05R\aafterId\x12\x14\n" +
+	"\x05limit\x18\b \x01(\x05R\x05limit\"\xa9\x02\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x17\n" +
+	"\atask_id\x18\x03 \x01(\x05R\x06taskId\x12\x14\n" +
+	"\x05actor\x18\x04 \x01(\tR\x05actor\x12\x1f\n" +
+	"\vclient_addr\x18\x05 \x01(\tR\n" +
+	"clientAddr\x12\x1f\n" +
+	"\vsource_addr\x18\x06 \x01(\tR\n" +
+	"sourceAddr\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\a \x01(\tR\trequestId\x12\x16\n" +
+	"\x06before\x18\b \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\t \x01(\tR\x05after\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\tR\tcreatedAt\x12\x16\n" +
+	"\x06detail\x18\v \x01(\tR\x06detail\"H\n" +
+	"\x17ListAuditEventsResponse\x12-\n" +
+	"\x06events\x18\x01 \x03(\v2\x15.scheduler.AuditEventR\x06events\"\xb7\x01\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x05R\x06taskId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12!\n" +
+	"\fcontent_type\x18\x04 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x06 \x01(\tR\x06sha256\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"R\n" +
+	"\x17ListAttachmentsResponse\x127\n" +
+	"\vattachments\x18\x01 \x03(\v2\x15.scheduler.AttachmentR\vattachments\"|\n" +
+	"\x0eAttachmentInfo\x12\x1f\n" +
+	"\atask_id\x18\x01 \x01(\x05B\x06\xa2\xbb\x18\x02\b\x01R\x06taskId\x12\x1d\n" +
+	"\x04name\x18\x02 \x01(\tB\t\xa2\xbb\x18\x05\b\x01\x10\xff\x01R\x04name\x12*\n" +
+	"\fcontent_type\x18\x03 \x01(\tB\a\xa2\xbb\x18\x03\x10\xff\x01R\vcontentType\"j\n" +
+	"\x17UploadAttachmentRequest\x12/\n" +
+	"\x04info\x18\x01 \x01(\v2\x19.scheduler.AttachmentInfoH\x00R\x04info\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"i\n" +
+	"\x1aDownloadAttachmentResponse\x12+\n" +
+	"\x04info\x18\x01 \x01(\v2\x15.scheduler.AttachmentH\x00R\x04info\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data*s\n" +
+	"\bPriority\x12\x18\n" +
+	"\x14PRIORITY_UNSPECIFIED\x10\x00\x12\x10\n" +
+	"\fPRIORITY_LOW\x10\x01\x12\x13\n" +
+	"\x0fPRIORITY_NORMAL\x10\x02\x12\x11\n" +
+	"\rPRIORITY_HIGH\x10\x03\x12\x13\n" +
+	"\x0fPRIORITY_URGENT\x10\x04*s\n" +
+	"\x06Status\x12\x16\n" +
+	"\x12STATUS_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vSTATUS_TODO\x10\x01\x12\x16\n" +
+	"\x12STATUS_IN_PROGRESS\x10\x02\x12\x12\n" +
+	"\x0eSTATUS_WAITING\x10\x03\x12\x14\n" +
+	"\x10STATUS_CANCELLED\x10\x042\xd5\x14\n" +
 	"\x10SchedulerService\x12F\n" +
 	"\tListTasks\x12\x1b.scheduler.ListTasksRequest\x1a\x1c.scheduler.ListTasksResponse\x12;\n" +
 	"\aGetTask\x12\x14.scheduler.IDRequest\x1a\x1a.scheduler.GetTaskResponse\x12D\n" +
 	"\n" +
 	"UpdateTask\x12\x1c.scheduler.UpdateTaskRequest\x1a\x18.scheduler.EmptyResponse\x12<\n" +
 	"\n" +
-	"DeleteTask\x12\x14.scheduler.IDRequest\x1a\x18.scheduler.EmptyResponse\x12:\n" +
-	"\bDoneTask\x12\x14.scheduler.IDRequest\x1a\x18.scheduler.EmptyResponse\x12C\n" +
+	"DeleteTask\x12\x14.scheduler.IDRequest\x1a\x18.scheduler.EmptyResponse\x12C\n" +
+	"\bDoneTask\x12\x1a.scheduler.DoneTaskRequest\x1a\x1b.scheduler.DoneTaskResponse\x12C\n" +
 	"\bNextDate\x12\x1a.scheduler.NextDateRequest\x1a\x1b.scheduler.NextDateResponse\x126\n" +
 	"\aAddTask\x12\x0f.scheduler.Task\x1a\x1a.scheduler.AddTaskResponse\x12D\n" +
 	"\n" +
-	"UpdateDate\x12\x1c.scheduler.UpdateDateRequest\x1a\x18.scheduler.EmptyResponseB\bZ\x06/protob\x06proto3"
+	"UpdateDate\x12\x1c.scheduler.UpdateDateRequest\x1a\x18.scheduler.EmptyResponse\x12D\n" +
+	"\n" +
+	"SnoozeTask\x12\x1c.scheduler.SnoozeTaskRequest\x1a\x18.scheduler.EmptyResponse\x12L\n" +
+	"\x0eSkipOccurrence\x12 .scheduler.SkipOccurrenceRequest\x1a\x18.scheduler.EmptyResponse\x12A\n" +
+	"\n" +
+	"ListMissed\x12\x14.scheduler.IDRequest\x1a\x1d.scheduler.ListMissedResponse\x12@\n" +
+	"\bListTags\x12\x17.scheduler.EmptyRequest\x1a\x1b.scheduler.ListTagsResponse\x128\n" +
+	"\tCreateTag\x12\x1b.scheduler.CreateTagRequest\x1a\x0e.scheduler.Tag\x128\n" +
+	"\tRenameTag\x12\x1b.scheduler.RenameTagRequest\x1a\x0e.scheduler.Tag\x12;\n" +
+	"\tDeleteTag\x12\x14.scheduler.IDRequest\x1a\x18.scheduler.EmptyResponse\x128\n" +
+	"\tMergeTags\x12\x1b.scheduler.MergeTagsRequest\x1a\x0e.scheduler.Tag\x12O\n" +
+	"\fListProjects\x12\x1e.scheduler.ListProjectsRequest\x1a\x1f.scheduler.ListProjectsResponse\x126\n" +
+	"\n" +
+	"GetProject\x12\x14.scheduler.IDRequest\x1a\x12.scheduler.Project\x127\n" +
+	"\rCreateProject\x12\x12.scheduler.Project\x1a\x12.scheduler.Project\x127\n" +
+	"\rUpdateProject\x12\x12.scheduler.Project\x1a\x12.scheduler.Project\x12F\n" +
+	"\x0eArchiveProject\x12 .scheduler.ArchiveProjectRequest\x1a\x12.scheduler.Project\x12?\n" +
+	"\rDeleteProject\x12\x14.scheduler.IDRequest\x1a\x18.scheduler.EmptyResponse\x12@\n" +
+	"\x0fGetProjectStats\x12\x14.scheduler.IDRequest\x1a\x17.scheduler.ProjectStats\x12B\n" +
+	"\fListSubtasks\x12\x14.scheduler.IDRequest\x1a\x1c.scheduler.ListTasksResponse\x129\n" +
+	"\tCheckItem\x12\x1b.scheduler.CheckItemRequest\x1a\x0f.scheduler.Task\x12>\n" +
+	"\rAddDependency\x12\x1c.scheduler.DependencyRequest\x1a\x0f.scheduler.Task\x12A\n" +
+	"\x10RemoveDependency\x12\x1c.scheduler.DependencyRequest\x1a\x0f.scheduler.Task\x129\n" +
+	"\tSetStatus\x12\x1b.scheduler.SetStatusRequest\x1a\x0f.scheduler.Task\x12O\n" +
+	"\x11ListStatusChanges\x12\x14.scheduler.IDRequest\x1a$.scheduler.ListStatusChangesResponse\x12G\n" +
+	"\rListRevisions\x12\x14.scheduler.IDRequest\x1a .scheduler.ListRevisionsResponse\x12B\n" +
+	"\vGetRevision\x12\x1a.scheduler.RevisionRequest\x1a\x17.scheduler.TaskRevision\x129\n" +
+	"\n" +
+	"RevertTask\x12\x1a.scheduler.RevisionRequest\x1a\x0f.scheduler.Task\x12K\n" +
+	"\x0fListAttachments\x12\x14.scheduler.IDRequest\x1a\".scheduler.ListAttachmentsResponse\x12O\n" +
+	"\x10UploadAttachment\x12\".scheduler.UploadAttachmentRequest\x1a\x15.scheduler.Attachment(\x01\x12S\n" +
+	"\x12DownloadAttachment\x12\x14.scheduler.IDRequest\x1a%.scheduler.DownloadAttachmentResponse0\x01\x12B\n" +
+	"\x10DeleteAttachment\x12\x14.scheduler.IDRequest\x1a\x18.scheduler.EmptyResponse\x12=\n" +
+	"\bGetClock\x12\x17.scheduler.EmptyRequest\x1a\x18.scheduler.ClockResponse\x12@\n" +
+	"\bSetClock\x12\x1a.scheduler.SetClockRequest\x1a\x18.scheduler.ClockResponse\x12X\n" +
+	"\x0fListAuditEvents\x12!.scheduler.ListAuditEventsRequest\x1a\".scheduler.ListAuditEventsResponseB\bZ\x06/protob\x06proto3"
 
 var (
 	file_task_proto_rawDescOnce sync.Once
@@ -622,45 +3467,167 @@ func file_task_proto_rawDescGZIP() []byte {
 	return file_task_proto_rawDescData
 }
 
-var file_task_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_task_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_task_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
 var file_task_proto_goTypes = []any{
-	(*Task)(nil),              // 0: scheduler.Task
-	(*ListTasksRequest)(nil),  // 1: scheduler.ListTasksRequest
-	(*ListTasksResponse)(nil), // 2: scheduler.ListTasksResponse
-	(*GetTaskResponse)(nil),   // 3: scheduler.GetTaskResponse
-	(*UpdateTaskRequest)(nil), // 4: scheduler.UpdateTaskRequest
-	(*IDRequest)(nil),         // 5: scheduler.IDRequest
-	(*NextDateRequest)(nil),   // 6: scheduler.NextDateRequest
-	(*NextDateResponse)(nil),  // 7: scheduler.NextDateResponse
-	(*AddTaskResponse)(nil),   // 8: scheduler.AddTaskResponse
-	(*UpdateDateRequest)(nil), // 9: scheduler.UpdateDateRequest
-	(*EmptyResponse)(nil),     // 10: scheduler.EmptyResponse
+	(Priority)(0),                      // 0: scheduler.Priority
+	(Status)(0),                        // 1: scheduler.Status
+	(*Task)(nil),                       // 2: scheduler.Task
+	(*ListTasksRequest)(nil),           // 3: scheduler.ListTasksRequest
+	(*ListTasksResponse)(nil),          // 4: scheduler.ListTasksResponse
+	(*GetTaskResponse)(nil),            // 5: scheduler.GetTaskResponse
+	(*UpdateTaskRequest)(nil),          // 6: scheduler.UpdateTaskRequest
+	(*IDRequest)(nil),                  // 7: scheduler.IDRequest
+	(*DoneTaskRequest)(nil),            // 8: scheduler.DoneTaskRequest
+	(*DoneTaskResponse)(nil),           // 9: scheduler.DoneTaskResponse
+	(*NextDateRequest)(nil),            // 10: scheduler.NextDateRequest
+	(*NextDateResponse)(nil),           // 11: scheduler.NextDateResponse
+	(*AddTaskResponse)(nil),            // 12: scheduler.AddTaskResponse
+	(*UpdateDateRequest)(nil),          // 13: scheduler.UpdateDateRequest
+	(*SnoozeTaskRequest)(nil),          // 14: scheduler.SnoozeTaskRequest
+	(*SkipOccurrenceRequest)(nil),      // 15: scheduler.SkipOccurrenceRequest
+	(*MissedOccurrence)(nil),           // 16: scheduler.MissedOccurrence
+	(*ListMissedResponse)(nil),         // 17: scheduler.ListMissedResponse
+	(*SetClockRequest)(nil),            // 18: scheduler.SetClockRequest
+	(*ClockResponse)(nil),              // 19: scheduler.ClockResponse
+	(*EmptyRequest)(nil),               // 20: scheduler.EmptyRequest
+	(*EmptyResponse)(nil),              // 21: scheduler.EmptyResponse
+	(*Tag)(nil),                        // 22: scheduler.Tag
+	(*ListTagsResponse)(nil),           // 23: scheduler.ListTagsResponse
+	(*CreateTagRequest)(nil),           // 24: scheduler.CreateTagRequest
+	(*RenameTagRequest)(nil),           // 25: scheduler.RenameTagRequest
+	(*MergeTagsRequest)(nil),           // 26: scheduler.MergeTagsRequest
+	(*Project)(nil),                    // 27: scheduler.Project
+	(*ListProjectsRequest)(nil),        // 28: scheduler.ListProjectsRequest
+	(*ListProjectsResponse)(nil),       // 29: scheduler.ListProjectsResponse
+	(*ArchiveProjectRequest)(nil),      // 30: scheduler.ArchiveProjectRequest
+	(*ProjectStats)(nil),               // 31: scheduler.ProjectStats
+	(*ChecklistItem)(nil),              // 32: scheduler.ChecklistItem
+	(*CheckItemRequest)(nil),           // 33: scheduler.CheckItemRequest
+	(*DependencyRequest)(nil),          // 34: scheduler.DependencyRequest
+	(*SetStatusRequest)(nil),           // 35: scheduler.SetStatusRequest
+	(*StatusChange)(nil),               // 36: scheduler.StatusChange
+	(*ListStatusChangesResponse)(nil),  // 37: scheduler.ListStatusChangesResponse
+	(*RevisionRequest)(nil),            // 38: scheduler.RevisionRequest
+	(*FieldChange)(nil),                // 39: scheduler.FieldChange
+	(*TaskRevision)(nil),               // 40: scheduler.TaskRevision
+	(*ListRevisionsResponse)(nil),      // 41: scheduler.ListRevisionsResponse
+	(*ListAuditEventsRequest)(nil),     // 42: scheduler.ListAuditEventsRequest
+	(*AuditEvent)(nil),                 // 43: scheduler.AuditEvent
+	(*ListAuditEventsResponse)(nil),    // 44: scheduler.ListAuditEventsResponse
+	(*Attachment)(nil),                 // 45: scheduler.Attachment
+	(*ListAttachmentsResponse)(nil),    // 46: scheduler.ListAttachmentsResponse
+	(*AttachmentInfo)(nil),             // 47: scheduler.AttachmentInfo
+	(*UploadAttachmentRequest)(nil),    // 48: scheduler.UploadAttachmentRequest
+	(*DownloadAttachmentResponse)(nil), // 49: scheduler.DownloadAttachmentResponse
 }
 var file_task_proto_depIdxs = []int32{
-	0,  // 0: scheduler.ListTasksResponse.tasks:type_name -> scheduler.Task
-	0,  // 1: scheduler.GetTaskResponse.task:type_name -> scheduler.Task
-	0,  // 2: scheduler.UpdateTaskRequest.task:type_name -> scheduler.Task
-	1,  // 3: scheduler.SchedulerService.ListTasks:input_type -> scheduler.ListTasksRequest
-	5,  // 4: scheduler.SchedulerService.GetTask:input_type -> scheduler.IDRequest
-	4,  // 5: scheduler.SchedulerService.UpdateTask:input_type -> scheduler.UpdateTaskRequest
-	5,  // 6: scheduler.SchedulerService.DeleteTask:input_type -> scheduler.IDRequest
-	5,  // 7: scheduler.SchedulerService.DoneTask:input_type -> scheduler.IDRequest
-	6,  // 8: scheduler.SchedulerService.NextDate:input_type -> scheduler.NextDateRequest
-	0,  // 9: scheduler.SchedulerService.AddTask:input_type -> scheduler.Task
-	9,  // 10: scheduler.SchedulerService.UpdateDate:input_type -> scheduler.UpdateDateRequest
-	2,  // 11: scheduler.SchedulerService.ListTasks:output_type -> scheduler.ListTasksResponse
-	3,  // 12: scheduler.SchedulerService.GetTask:output_type -> scheduler.GetTaskResponse
-	10, // 13: scheduler.SchedulerService.UpdateTask:output_type -> scheduler.EmptyResponse
-	10, // 14: scheduler.SchedulerService.DeleteTask:output_type -> scheduler.EmptyResponse
-	10, // 15: scheduler.SchedulerService.DoneTask:output_type -> scheduler.EmptyResponse
-	7,  // 16: scheduler.SchedulerService.NextDate:output_type -> scheduler.NextDateResponse
-	8,  // 17: scheduler.SchedulerService.AddTask:output_type -> scheduler.AddTaskResponse
-	10, // 18: scheduler.SchedulerService.UpdateDate:output_type -> scheduler.EmptyResponse
-	11, // [11:19] is the sub-list for method output_type
-	3,  // [3:11] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	0,  // 0: scheduler.Task.priority:type_name -> scheduler.Priority
+	32, // 1: scheduler.Task.checklist:type_name -> scheduler.ChecklistItem
+	1,  // 2: scheduler.Task.status:type_name -> scheduler.Status
+	0,  // 3: scheduler.ListTasksRequest.min_priority:type_name -> scheduler.Priority
+	1,  // 4: scheduler.ListTasksRequest.statuses:type_name -> scheduler.Status
+	2,  // 5: scheduler.ListTasksResponse.tasks:type_name -> scheduler.Task
+	2,  // 6: scheduler.GetTaskResponse.task:type_name -> scheduler.Task
+	2,  // 7: scheduler.UpdateTaskRequest.task:type_name -> scheduler.Task
+	2,  // 8: scheduler.DoneTaskResponse.task:type_name -> scheduler.Task
+	16, // 9: scheduler.ListMissedResponse.missed:type_name -> scheduler.MissedOccurrence
+	22, // 10: scheduler.ListTagsResponse.tags:type_name -> scheduler.Tag
+	27, // 11: scheduler.ListProjectsResponse.projects:type_name -> scheduler.Project
+	1,  // 12: scheduler.SetStatusRequest.status:type_name -> scheduler.Status
+	1,  // 13: scheduler.StatusChange.from:type_name -> scheduler.Status
+	1,  // 14: scheduler.StatusChange.to:type_name -> scheduler.Status
+	36, // 15: scheduler.ListStatusChangesResponse.changes:type_name -> scheduler.StatusChange
+	2,  // 16: scheduler.TaskRevision.snapshot:type_name -> scheduler.Task
+	39, // 17: scheduler.TaskRevision.changes:type_name -> scheduler.FieldChange
+	40, // 18: scheduler.ListRevisionsResponse.revisions:type_name -> scheduler.TaskRevision
+	43, // 19: scheduler.ListAuditEventsResponse.events:type_name -> scheduler.AuditEvent
+	45, // 20: scheduler.ListAttachmentsResponse.attachments:type_name -> scheduler.Attachment
+	47, // 21: scheduler.UploadAttachmentRequest.info:type_name -> scheduler.AttachmentInfo
+	45, // 22: scheduler.DownloadAttachmentResponse.info:type_name -> scheduler.Attachment
+	3,  // 23: scheduler.SchedulerService.ListTasks:input_type -> scheduler.ListTasksRequest
+	7,  // 24: scheduler.SchedulerService.GetTask:input_type -> scheduler.IDRequest
+	6,  // 25: scheduler.SchedulerService.UpdateTask:input_type -> scheduler.UpdateTaskRequest
+	7,  // 26: scheduler.SchedulerService.DeleteTask:input_type -> scheduler.IDRequest
+	8,  // 27: scheduler.SchedulerService.DoneTask:input_type -> scheduler.DoneTaskRequest
+	10, // 28: scheduler.SchedulerService.NextDate:input_type -> scheduler.NextDateRequest
+	2,  // 29: scheduler.SchedulerService.AddTask:input_type -> scheduler.Task
+	13, // 30: scheduler.SchedulerService.UpdateDate:input_type -> scheduler.UpdateDateRequest
+	14, // 31: scheduler.SchedulerService.SnoozeTask:input_type -> scheduler.SnoozeTaskRequest
+	15, // 32: scheduler.SchedulerService.SkipOccurrence:input_type -> scheduler.SkipOccurrenceRequest
+	7,  // 33: scheduler.SchedulerService.ListMissed:input_type -> scheduler.IDRequest
+	20, // 34: scheduler.SchedulerService.ListTags:input_type -> scheduler.EmptyRequest
+	24, // 35: scheduler.SchedulerService.CreateTag:input_type -> scheduler.CreateTagRequest
+	25, // 36: scheduler.SchedulerService.RenameTag:input_type -> scheduler.RenameTagRequest
+	7,  // 37: scheduler.SchedulerService.DeleteTag:input_type -> scheduler.IDRequest
+	26, // 38: scheduler.SchedulerService.MergeTags:input_type -> scheduler.MergeTagsRequest
+	28, // 39: scheduler.SchedulerService.ListProjects:input_type -> scheduler.ListProjectsRequest
+	7,  // 40: scheduler.SchedulerService.GetProject:input_type -> scheduler.IDRequest
+	27, // 41: scheduler.SchedulerService.CreateProject:input_type -> scheduler.Project
+	27, // 42: scheduler.SchedulerService.UpdateProject:input_type -> scheduler.Project
+	30, // 43: scheduler.SchedulerService.ArchiveProject:input_type -> scheduler.ArchiveProjectRequest
+	7,  // 44: scheduler.SchedulerService.DeleteProject:input_type -> scheduler.IDRequest
+	7,  // 45: scheduler.SchedulerService.GetProjectStats:input_type -> scheduler.IDRequest
+	7,  // 46: scheduler.SchedulerService.ListSubtasks:input_type -> scheduler.IDRequest
+	33, // 47: scheduler.SchedulerService.CheckItem:input_type -> scheduler.CheckItemRequest
+	34, // 48: scheduler.SchedulerService.AddDependency:input_type -> scheduler.DependencyRequest
+	34, // 49: scheduler.SchedulerService.RemoveDependency:input_type -> scheduler.DependencyRequest
+	35, // 50: scheduler.SchedulerService.SetStatus:input_type -> scheduler.SetStatusRequest
+	7,  // 51: scheduler.SchedulerService.ListStatusChanges:input_type -> scheduler.IDRequest
+	7,  // 52: scheduler.SchedulerService.ListRevisions:input_type -> scheduler.IDRequest
+	38, // 53: scheduler.SchedulerService.GetRevision:input_type -> scheduler.RevisionRequest
+	38, // 54: scheduler.SchedulerService.RevertTask:input_type -> scheduler.RevisionRequest
+	7,  // 55: scheduler.SchedulerService.ListAttachments:input_type -> scheduler.IDRequest
+	48, // 56: scheduler.SchedulerService.UploadAttachment:input_type -> scheduler.UploadAttachmentRequest
+	7,  // 57: scheduler.SchedulerService.DownloadAttachment:input_type -> scheduler.IDRequest
+	7,  // 58: scheduler.SchedulerService.DeleteAttachment:input_type -> scheduler.IDRequest
+	20, // 59: scheduler.SchedulerService.GetClock:input_type -> scheduler.EmptyRequest
+	18, // 60: scheduler.SchedulerService.SetClock:input_type -> scheduler.SetClockRequest
+	42, // 61: scheduler.SchedulerService.ListAuditEvents:input_type -> scheduler.ListAuditEventsRequest
+	4,  // 62: scheduler.SchedulerService.ListTasks:output_type -> scheduler.ListTasksResponse
+	5,  // 63: scheduler.SchedulerService.GetTask:output_type -> scheduler.GetTaskResponse
+	21, // 64: scheduler.SchedulerService.UpdateTask:output_type -> scheduler.EmptyResponse
+	21, // 65: scheduler.SchedulerService.DeleteTask:output_type -> scheduler.EmptyResponse
+	9,  // 66: scheduler.SchedulerService.DoneTask:output_type -> scheduler.DoneTaskResponse
+	11, // 67: scheduler.SchedulerService.NextDate:output_type -> scheduler.NextDateResponse
+	12, // 68: scheduler.SchedulerService.AddTask:output_type -> scheduler.AddTaskResponse
+	21, // 69: scheduler.SchedulerService.UpdateDate:output_type -> scheduler.EmptyResponse
+	21, // 70: scheduler.SchedulerService.SnoozeTask:output_type -> scheduler.EmptyResponse
+	21, // 71: scheduler.SchedulerService.SkipOccurrence:output_type -> scheduler.EmptyResponse
+	17, // 72: scheduler.SchedulerService.ListMissed:output_type -> scheduler.ListMissedResponse
+	23, // 73: scheduler.SchedulerService.ListTags:output_type -> scheduler.ListTagsResponse
+	22, // 74: scheduler.SchedulerService.CreateTag:output_type -> scheduler.Tag
+	22, // 75: scheduler.SchedulerService.RenameTag:output_type -> scheduler.Tag
+	21, // 76: scheduler.SchedulerService.DeleteTag:output_type -> scheduler.EmptyResponse
+	22, // 77: scheduler.SchedulerService.MergeTags:output_type -> scheduler.Tag
+	29, // 78: scheduler.SchedulerService.ListProjects:output_type -> scheduler.ListProjectsResponse
+	27, // 79: scheduler.SchedulerService.GetProject:output_type -> scheduler.Project
+	27, // 80: scheduler.SchedulerService.CreateProject:output_type -> scheduler.Project
+	27, // 81: scheduler.SchedulerService.UpdateProject:output_type -> scheduler.Project
+	27, // 82: scheduler.SchedulerService.ArchiveProject:output_type -> scheduler.Project
+	21, // 83: scheduler.SchedulerService.DeleteProject:output_type -> scheduler.EmptyResponse
+	31, // 84: scheduler.SchedulerService.GetProjectStats:output_type -> scheduler.ProjectStats
+	4,  // 85: scheduler.SchedulerService.ListSubtasks:output_type -> scheduler.ListTasksResponse
+	2,  // 86: scheduler.SchedulerService.CheckItem:output_type -> scheduler.Task
+	2,  // 87: scheduler.SchedulerService.AddDependency:output_type -> scheduler.Task
+	2,  // 88: scheduler.SchedulerService.RemoveDependency:output_type -> scheduler.Task
+	2,  // 89: scheduler.SchedulerService.SetStatus:output_type -> scheduler.Task
+	37, // 90: scheduler.SchedulerService.ListStatusChanges:output_type -> scheduler.ListStatusChangesResponse
+	41, // 91: scheduler.SchedulerService.ListRevisions:output_type -> scheduler.ListRevisionsResponse
+	40, // 92: scheduler.SchedulerService.GetRevision:output_type -> scheduler.TaskRevision
+	2,  // 93: scheduler.SchedulerService.RevertTask:output_type -> scheduler.Task
+	46, // 94: scheduler.SchedulerService.ListAttachments:output_type -> scheduler.ListAttachmentsResponse
+	45, // 95: scheduler.SchedulerService.UploadAttachment:output_type -> scheduler.Attachment
+	49, // 96: scheduler.SchedulerService.DownloadAttachment:output_type -> scheduler.DownloadAttachmentResponse
+	21, // 97: scheduler.SchedulerService.DeleteAttachment:output_type -> scheduler.EmptyResponse
+	19, // 98: scheduler.SchedulerService.GetClock:output_type -> scheduler.ClockResponse
+	19, // 99: scheduler.SchedulerService.SetClock:output_type -> scheduler.ClockResponse
+	44, // 100: scheduler.SchedulerService.ListAuditEvents:output_type -> scheduler.ListAuditEventsResponse
+	62, // [62:101] is the sub-list for method output_type
+	23, // [23:62] is the sub-list for method input_type
+	23, // [23:23] is the sub-list for extension type_name
+	23, // [23:23] is the sub-list for extension extendee
+	0,  // [0:23] is the sub-list for field type_name
 }
 
 func init() { file_task_proto_init() }
@@ -668,18 +3635,29 @@ func file_task_proto_init() {
 	if File_task_proto != nil {
 		return
 	}
+	file_validate_proto_init()
+	file_task_proto_msgTypes[11].OneofWrappers = []any{}
+	file_task_proto_msgTypes[46].OneofWrappers = []any{
+		(*UploadAttachmentRequest_Info)(nil),
+		(*UploadAttachmentRequest_Chunk)(nil),
+	}
+	file_task_proto_msgTypes[47].OneofWrappers = []any{
+		(*DownloadAttachmentResponse_Info)(nil),
+		(*DownloadAttachmentResponse_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_task_proto_rawDesc), len(file_task_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   11,
+			NumEnums:      2,
+			NumMessages:   48,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_task_proto_goTypes,
 		DependencyIndexes: file_task_proto_depIdxs,
+		EnumInfos:         file_task_proto_enumTypes,
 		MessageInfos:      file_task_proto_msgTypes,
 	}.Build()
 	File_task_proto = out.File
@@ -19,14 +19,45 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SchedulerService_ListTasks_FullMethodName  = "/scheduler.SchedulerService/ListTasks"
-	SchedulerService_GetTask_FullMethodName    = "/scheduler.SchedulerService/GetTask"
-	SchedulerService_UpdateTask_FullMethodName = "/scheduler.SchedulerService/UpdateTask"
-	SchedulerService_DeleteTask_FullMethodName = "/scheduler.SchedulerService/DeleteTask"
-	SchedulerService_DoneTask_FullMethodName   = "/scheduler.SchedulerService/DoneTask"
-	SchedulerService_NextDate_FullMethodName   = "/scheduler.SchedulerService/NextDate"
-	SchedulerService_AddTask_FullMethodName    = "/scheduler.SchedulerService/AddTask"
-	SchedulerService_UpdateDate_FullMethodName = "/scheduler.SchedulerService/UpdateDate"
+	SchedulerService_ListTasks_FullMethodName          = "/scheduler.SchedulerService/ListTasks"
+	SchedulerService_GetTask_FullMethodName            = "/scheduler.SchedulerService/GetTask"
+	SchedulerService_UpdateTask_FullMethodName         = "/scheduler.SchedulerService/UpdateTask"
+	SchedulerService_DeleteTask_FullMethodName         = "/scheduler.SchedulerService/DeleteTask"
+	SchedulerService_DoneTask_FullMethodName           = "/scheduler.SchedulerService/DoneTask"
+	SchedulerService_NextDate_FullMethodName           = "/scheduler.SchedulerService/NextDate"
+	SchedulerService_AddTask_FullMethodName            = "/scheduler.SchedulerService/AddTask"
+	SchedulerService_UpdateDate_FullMethodName         = "/scheduler.SchedulerService/UpdateDate"
+	SchedulerService_SnoozeTask_FullMethodName         = "/scheduler.SchedulerService/SnoozeTask"
+	SchedulerService_SkipOccurrence_FullMethodName     = "/scheduler.SchedulerService/SkipOccurrence"
+	SchedulerService_ListMissed_FullMethodName         = "/scheduler.SchedulerService/ListMissed"
+	SchedulerService_ListTags_FullMethodName           = "/scheduler.SchedulerService/ListTags"
+	SchedulerService_CreateTag_FullMethodName          = "/scheduler.SchedulerService/CreateTag"
+	SchedulerService_RenameTag_FullMethodName          = "/scheduler.SchedulerService/RenameTag"
+	SchedulerService_DeleteTag_FullMethodName          = "/scheduler.SchedulerService/DeleteTag"
+	SchedulerService_MergeTags_FullMethodName          = "/scheduler.SchedulerService/MergeTags"
+	SchedulerService_ListProjects_FullMethodName       = "/scheduler.SchedulerService/ListProjects"
+	SchedulerService_GetProject_FullMethodName         = "/scheduler.SchedulerService/GetProject"
+	SchedulerService_CreateProject_FullMethodName      = "/scheduler.SchedulerService/CreateProject"
+	SchedulerService_UpdateProject_FullMethodName      = "/scheduler.SchedulerService/UpdateProject"
+	SchedulerService_ArchiveProject_FullMethodName     = "/scheduler.SchedulerService/ArchiveProject"
+	SchedulerService_DeleteProject_FullMethodName      = "/scheduler.SchedulerService/DeleteProject"
+	SchedulerService_GetProjectStats_FullMethodName    = "/scheduler.SchedulerService/GetProjectStats"
+	SchedulerService_ListSubtasks_FullMethodName       = "/scheduler.SchedulerService/ListSubtasks"
+	SchedulerService_CheckItem_FullMethodName          = "/scheduler.SchedulerService/CheckItem"
+	SchedulerService_AddDependency_FullMethodName      = "/scheduler.SchedulerService/AddDependency"
+	SchedulerService_RemoveDependency_FullMethodName   = "/scheduler.SchedulerService/RemoveDependency"
+	SchedulerService_SetStatus_FullMethodName          = "/scheduler.SchedulerService/SetStatus"
+	SchedulerService_ListStatusChanges_FullMethodName  = "/scheduler.SchedulerService/ListStatusChanges"
+	SchedulerService_ListRevisions_FullMethodName      = "/scheduler.SchedulerService/ListRevisions"
+	SchedulerService_GetRevision_FullMethodName        = "/scheduler.SchedulerService/GetRevision"
+	SchedulerService_RevertTask_FullMethodName         = "/scheduler.SchedulerService/RevertTask"
+	SchedulerService_ListAttachments_FullMethodName    = "/scheduler.SchedulerService/ListAttachments"
+	SchedulerService_UploadAttachment_FullMethodName   = "/scheduler.SchedulerService/UploadAttachment"
+	SchedulerService_DownloadAttachment_FullMethodName = "/scheduler.SchedulerService/DownloadAttachment"
+	SchedulerService_DeleteAttachment_FullMethodName   = "/scheduler.SchedulerService/DeleteAttachment"
+	SchedulerService_GetClock_FullMethodName           = "/scheduler.SchedulerService/GetClock"
+	SchedulerService_SetClock_FullMethodName           = "/scheduler.SchedulerService/SetClock"
+	SchedulerService_ListAuditEvents_FullMethodName    = "/scheduler.SchedulerService/ListAuditEvents"
 )
 
 // SchedulerServiceClient is the client API for SchedulerService service.
@@ -37,10 +68,44 @@ type SchedulerServiceClient interface {
 	GetTask(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*GetTaskResponse, error)
 	UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
 	DeleteTask(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
-	DoneTask(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	DoneTask(ctx context.Context, in *DoneTaskRequest, opts ...grpc.CallOption) (*DoneTaskResponse, error)
 	NextDate(ctx context.Context, in *NextDateRequest, opts ...grpc.CallOption) (*NextDateResponse, error)
 	AddTask(ctx context.Context, in *Task, opts ...grpc.CallOption) (*AddTaskResponse, error)
 	UpdateDate(ctx context.Context, in *UpdateDateRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	SnoozeTask(ctx context.Context, in *SnoozeTaskRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	SkipOccurrence(ctx context.Context, in *SkipOccurrenceRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	ListMissed(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ListMissedResponse, error)
+	ListTags(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ListTagsResponse, error)
+	CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*Tag, error)
+	RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*Tag, error)
+	DeleteTag(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*Tag, error)
+	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
+	GetProject(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Project, error)
+	CreateProject(ctx context.Context, in *Project, opts ...grpc.CallOption) (*Project, error)
+	UpdateProject(ctx context.Context, in *Project, opts ...grpc.CallOption) (*Project, error)
+	ArchiveProject(ctx context.Context, in *ArchiveProjectRequest, opts ...grpc.CallOption) (*Project, error)
+	DeleteProject(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	GetProjectStats(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ProjectStats, error)
+	ListSubtasks(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	CheckItem(ctx context.Context, in *CheckItemRequest, opts ...grpc.CallOption) (*Task, error)
+	AddDependency(ctx context.Context, in *DependencyRequest, opts ...grpc.CallOption) (*Task, error)
+	RemoveDependency(ctx context.Context, in *DependencyRequest, opts ...grpc.CallOption) (*Task, error)
+	SetStatus(ctx context.Context, in *SetStatusRequest, opts ...grpc.CallOption) (*Task, error)
+	ListStatusChanges(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ListStatusChangesResponse, error)
+	ListRevisions(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error)
+	GetRevision(ctx context.Context, in *RevisionRequest, opts ...grpc.CallOption) (*TaskRevision, error)
+	RevertTask(ctx context.Context, in *RevisionRequest, opts ...grpc.CallOption) (*Task, error)
+	// вложения: содержимое передается потоком частей, первое сообщение потока — описание файла
+	ListAttachments(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error)
+	UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, Attachment], error)
+	DownloadAttachment(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadAttachmentResponse], error)
+	DeleteAttachment(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*EmptyResponse, error)
+	// админские методы стенда, требуют x-admin-token в метаданных
+	GetClock(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ClockResponse, error)
+	SetClock(ctx context.Context, in *SetClockRequest, opts ...grpc.CallOption) (*ClockResponse, error)
+	// журнал аудита, тоже требует x-admin-token
+	ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error)
 }
 
 type schedulerServiceClient struct {
@@ -91,9 +156,9 @@ func (c *schedulerServiceClient) DeleteTask(ctx context.Context, in *IDRequest,
 	return out, nil
 }
 
-func (c *schedulerServiceClient) DoneTask(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+func (c *schedulerServiceClient) DoneTask(ctx context.Context, in *DoneTaskRequest, opts ...grpc.CallOption) (*DoneTaskResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(EmptyResponse)
+	out := new(DoneTaskResponse)
 	err := c.cc.Invoke(ctx, SchedulerService_DoneTask_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -101,243 +166,1232 @@ func (c *schedulerServiceClient) DoneTask(ctx context.Context, in *IDRequest, op
 	return out, nil
 }
 
-func (c *schedulerServiceClient) NextDate(ctx context.Context, in *NextDateRequest, opts ...grpc.CallOption) (*NextDateResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(NextDateResponse)
-	err := c.cc.Invoke(ctx, SchedulerService_NextDate_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func (c *schedulerServiceClient) NextDate(ctx context.Context, in *NextDateRequest, opts ...grpc.CallOption) (*NextDateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NextDateResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_NextDate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) AddTask(ctx context.Context, in *Task, opts ...grpc.CallOption) (*AddTaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddTaskResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_AddTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) UpdateDate(ctx context.Context, in *UpdateDateRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_UpdateDate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) SnoozeTask(ctx context.Context, in *SnoozeTaskRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_SnoozeTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) SkipOccurrence(ctx context.Context, in *SkipOccurrenceRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_SkipOccurrence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ListMissed(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ListMissedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMissedResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ListMissed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ListTags(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ListTagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTagsResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ListTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*Tag, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tag)
+	err := c.cc.Invoke(ctx, SchedulerService_CreateTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) RenameTag(ctx context.Context, in *RenameTagRequest, opts ...grpc.CallOption) (*Tag, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tag)
+	err := c.cc.Invoke(ctx, SchedulerService_RenameTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) DeleteTag(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_DeleteTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) MergeTags(ctx context.Context, in *MergeTagsRequest, opts ...grpc.CallOption) (*Tag, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Tag)
+	err := c.cc.Invoke(ctx, SchedulerService_MergeTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProjectsResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ListProjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetProject(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*Project, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Project)
+	err := c.cc.Invoke(ctx, SchedulerService_GetProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) CreateProject(ctx context.Context, in *Project, opts ...grpc.CallOption) (*Project, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Project)
+	err := c.cc.Invoke(ctx, SchedulerService_CreateProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) UpdateProject(ctx context.Context, in *Project, opts ...grpc.CallOption) (*Project, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Project)
+	err := c.cc.Invoke(ctx, SchedulerService_UpdateProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ArchiveProject(ctx context.Context, in *ArchiveProjectRequest, opts ...grpc.CallOption) (*Project, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Project)
+	err := c.cc.Invoke(ctx, SchedulerService_ArchiveProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) DeleteProject(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_DeleteProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetProjectStats(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ProjectStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProjectStats)
+	err := c.cc.Invoke(ctx, SchedulerService_GetProjectStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ListSubtasks(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ListSubtasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) CheckItem(ctx context.Context, in *CheckItemRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, SchedulerService_CheckItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) AddDependency(ctx context.Context, in *DependencyRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, SchedulerService_AddDependency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) RemoveDependency(ctx context.Context, in *DependencyRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, SchedulerService_RemoveDependency_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) SetStatus(ctx context.Context, in *SetStatusRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, SchedulerService_SetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ListStatusChanges(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ListStatusChangesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStatusChangesResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ListStatusChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ListRevisions(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRevisionsResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ListRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetRevision(ctx context.Context, in *RevisionRequest, opts ...grpc.CallOption) (*TaskRevision, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskRevision)
+	err := c.cc.Invoke(ctx, SchedulerService_GetRevision_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) RevertTask(ctx context.Context, in *RevisionRequest, opts ...grpc.CallOption) (*Task, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Task)
+	err := c.cc.Invoke(ctx, SchedulerService_RevertTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ListAttachments(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAttachmentsResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ListAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentRequest, Attachment], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SchedulerService_ServiceDesc.Streams[0], SchedulerService_UploadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadAttachmentRequest, Attachment]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SchedulerService_UploadAttachmentClient = grpc.ClientStreamingClient[UploadAttachmentRequest, Attachment]
+
+func (c *schedulerServiceClient) DownloadAttachment(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadAttachmentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SchedulerService_ServiceDesc.Streams[1], SchedulerService_DownloadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[IDRequest, DownloadAttachmentResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SchedulerService_DownloadAttachmentClient = grpc.ServerStreamingClient[DownloadAttachmentResponse]
+
+func (c *schedulerServiceClient) DeleteAttachment(ctx context.Context, in *IDRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EmptyResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_DeleteAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) GetClock(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*ClockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClockResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_GetClock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) SetClock(ctx context.Context, in *SetClockRequest, opts ...grpc.CallOption) (*ClockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClockResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_SetClock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schedulerServiceClient) ListAuditEvents(ctx context.Context, in *ListAuditEventsRequest, opts ...grpc.CallOption) (*ListAuditEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAuditEventsResponse)
+	err := c.cc.Invoke(ctx, SchedulerService_ListAuditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SchedulerServiceServer is the server API for SchedulerService service.
+// All implementations must embed UnimplementedSchedulerServiceServer
+// for forward compatibility.
+type SchedulerServiceServer interface {
+	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	GetTask(context.Context, *IDRequest) (*GetTaskResponse, error)
+	UpdateTask(context.Context, *UpdateTaskRequest) (*EmptyResponse, error)
+	DeleteTask(context.Context, *IDRequest) (*EmptyResponse, error)
+	DoneTask(context.Context, *DoneTaskRequest) (*DoneTaskResponse, error)
+	NextDate(context.Context, *NextDateRequest) (*NextDateResponse, error)
+	AddTask(context.Context, *Task) (*AddTaskResponse, error)
+	UpdateDate(context.Context, *UpdateDateRequest) (*EmptyResponse, error)
+	SnoozeTask(context.Context, *SnoozeTaskRequest) (*EmptyResponse, error)
+	SkipOccurrence(context.Context, *SkipOccurrenceRequest) (*EmptyResponse, error)
+	ListMissed(context.Context, *IDRequest) (*ListMissedResponse, error)
+	ListTags(context.Context, *EmptyRequest) (*ListTagsResponse, error)
+	CreateTag(context.Context, *CreateTagRequest) (*Tag, error)
+	RenameTag(context.Context, *RenameTagRequest) (*Tag, error)
+	DeleteTag(context.Context, *IDRequest) (*EmptyResponse, error)
+	MergeTags(context.Context, *MergeTagsRequest) (*Tag, error)
+	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
+	GetProject(context.Context, *IDRequest) (*Project, error)
+	CreateProject(context.Context, *Project) (*Project, error)
+	UpdateProject(context.Context, *Project) (*Project, error)
+	ArchiveProject(context.Context, *ArchiveProjectRequest) (*Project, error)
+	DeleteProject(context.Context, *IDRequest) (*EmptyResponse, error)
+	GetProjectStats(context.Context, *IDRequest) (*ProjectStats, error)
+	ListSubtasks(context.Context, *IDRequest) (*ListTasksResponse, error)
+	CheckItem(context.Context, *CheckItemRequest) (*Task, error)
+	AddDependency(context.Context, *DependencyRequest) (*Task, error)
+	RemoveDependency(context.Context, *DependencyRequest) (*Task, error)
+	SetStatus(context.Context, *SetStatusRequest) (*Task, error)
+	ListStatusChanges(context.Context, *IDRequest) (*ListStatusChangesResponse, error)
+	ListRevisions(context.Context, *IDRequest) (*ListRevisionsResponse, error)
+	GetRevision(context.Context, *RevisionRequest) (*TaskRevision, error)
+	RevertTask(context.Context, *RevisionRequest) (*Task, error)
+	// вложения: содержимое передается потоком частей, первое сообщение потока — описание файла
+	ListAttachments(context.Context, *IDRequest) (*ListAttachmentsResponse, error)
+	UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, Attachment]) error
+	DownloadAttachment(*IDRequest, grpc.ServerStreamingServer[DownloadAttachmentResponse]) error
+	DeleteAttachment(context.Context, *IDRequest) (*EmptyResponse, error)
+	// админские методы стенда, требуют x-admin-token в метаданных
+	GetClock(context.Context, *EmptyRequest) (*ClockResponse, error)
+	SetClock(context.Context, *SetClockRequest) (*ClockResponse, error)
+	// журнал аудита, тоже требует x-admin-token
+	ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error)
+	mustEmbedUnimplementedSchedulerServiceServer()
+}
+
+// UnimplementedSchedulerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSchedulerServiceServer struct{}
+
+func (UnimplementedSchedulerServiceServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedSchedulerServiceServer) GetTask(context.Context, *IDRequest) (*GetTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
+}
+func (UnimplementedSchedulerServiceServer) UpdateTask(context.Context, *UpdateTaskRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTask not implemented")
+}
+func (UnimplementedSchedulerServiceServer) DeleteTask(context.Context, *IDRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
+}
+func (UnimplementedSchedulerServiceServer) DoneTask(context.Context, *DoneTaskRequest) (*DoneTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DoneTask not implemented")
+}
+func (UnimplementedSchedulerServiceServer) NextDate(context.Context, *NextDateRequest) (*NextDateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextDate not implemented")
+}
+func (UnimplementedSchedulerServiceServer) AddTask(context.Context, *Task) (*AddTaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddTask not implemented")
+}
+func (UnimplementedSchedulerServiceServer) UpdateDate(context.Context, *UpdateDateRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateDate not implemented")
+}
+func (UnimplementedSchedulerServiceServer) SnoozeTask(context.Context, *SnoozeTaskRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SnoozeTask not implemented")
+}
+func (UnimplementedSchedulerServiceServer) SkipOccurrence(context.Context, *SkipOccurrenceRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SkipOccurrence not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ListMissed(context.Context, *IDRequest) (*ListMissedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMissed not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ListTags(context.Context, *EmptyRequest) (*ListTagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTags not implemented")
+}
+func (UnimplementedSchedulerServiceServer) CreateTag(context.Context, *CreateTagRequest) (*Tag, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTag not implemented")
+}
+func (UnimplementedSchedulerServiceServer) RenameTag(context.Context, *RenameTagRequest) (*Tag, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameTag not implemented")
+}
+func (UnimplementedSchedulerServiceServer) DeleteTag(context.Context, *IDRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTag not implemented")
+}
+func (UnimplementedSchedulerServiceServer) MergeTags(context.Context, *MergeTagsRequest) (*Tag, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeTags not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
+}
+func (UnimplementedSchedulerServiceServer) GetProject(context.Context, *IDRequest) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProject not implemented")
+}
+func (UnimplementedSchedulerServiceServer) CreateProject(context.Context, *Project) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProject not implemented")
+}
+func (UnimplementedSchedulerServiceServer) UpdateProject(context.Context, *Project) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProject not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ArchiveProject(context.Context, *ArchiveProjectRequest) (*Project, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveProject not implemented")
+}
+func (UnimplementedSchedulerServiceServer) DeleteProject(context.Context, *IDRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProject not implemented")
+}
+func (UnimplementedSchedulerServiceServer) GetProjectStats(context.Context, *IDRequest) (*ProjectStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProjectStats not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ListSubtasks(context.Context, *IDRequest) (*ListTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubtasks not implemented")
+}
+func (UnimplementedSchedulerServiceServer) CheckItem(context.Context, *CheckItemRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckItem not implemented")
+}
+func (UnimplementedSchedulerServiceServer) AddDependency(context.Context, *DependencyRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDependency not implemented")
+}
+func (UnimplementedSchedulerServiceServer) RemoveDependency(context.Context, *DependencyRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveDependency not implemented")
+}
+func (UnimplementedSchedulerServiceServer) SetStatus(context.Context, *SetStatusRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStatus not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ListStatusChanges(context.Context, *IDRequest) (*ListStatusChangesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStatusChanges not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ListRevisions(context.Context, *IDRequest) (*ListRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRevisions not implemented")
+}
+func (UnimplementedSchedulerServiceServer) GetRevision(context.Context, *RevisionRequest) (*TaskRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevision not implemented")
+}
+func (UnimplementedSchedulerServiceServer) RevertTask(context.Context, *RevisionRequest) (*Task, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertTask not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ListAttachments(context.Context, *IDRequest) (*ListAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttachments not implemented")
+}
+func (UnimplementedSchedulerServiceServer) UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentRequest, Attachment]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (UnimplementedSchedulerServiceServer) DownloadAttachment(*IDRequest, grpc.ServerStreamingServer[DownloadAttachmentResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadAttachment not implemented")
+}
+func (UnimplementedSchedulerServiceServer) DeleteAttachment(context.Context, *IDRequest) (*EmptyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttachment not implemented")
+}
+func (UnimplementedSchedulerServiceServer) GetClock(context.Context, *EmptyRequest) (*ClockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClock not implemented")
+}
+func (UnimplementedSchedulerServiceServer) SetClock(context.Context, *SetClockRequest) (*ClockResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetClock not implemented")
+}
+func (UnimplementedSchedulerServiceServer) ListAuditEvents(context.Context, *ListAuditEventsRequest) (*ListAuditEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAuditEvents not implemented")
+}
+func (UnimplementedSchedulerServiceServer) mustEmbedUnimplementedSchedulerServiceServer() {}
+func (UnimplementedSchedulerServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeSchedulerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SchedulerServiceServer will
+// result in compilation errors.
+type UnsafeSchedulerServiceServer interface {
+	mustEmbedUnimplementedSchedulerServiceServer()
+}
+
+func RegisterSchedulerServiceServer(s grpc.ServiceRegistrar, srv SchedulerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSchedulerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SchedulerService_ServiceDesc, srv)
+}
+
+func _SchedulerService_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ListTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ListTasks(ctx, req.(*ListTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).GetTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_GetTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).GetTask(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_UpdateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).UpdateTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_UpdateTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).UpdateTask(ctx, req.(*UpdateTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_DeleteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).DeleteTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_DeleteTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).DeleteTask(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_DoneTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DoneTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).DoneTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_DoneTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).DoneTask(ctx, req.(*DoneTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_NextDate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextDateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).NextDate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_NextDate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).NextDate(ctx, req.(*NextDateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_AddTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Task)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).AddTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_AddTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).AddTask(ctx, req.(*Task))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_UpdateDate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateDateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).UpdateDate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_UpdateDate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).UpdateDate(ctx, req.(*UpdateDateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_SnoozeTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnoozeTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).SnoozeTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_SnoozeTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).SnoozeTask(ctx, req.(*SnoozeTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_SkipOccurrence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SkipOccurrenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).SkipOccurrence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_SkipOccurrence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).SkipOccurrence(ctx, req.(*SkipOccurrenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_ListMissed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ListMissed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ListMissed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ListMissed(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_ListTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ListTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ListTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ListTags(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_CreateTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).CreateTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_CreateTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).CreateTag(ctx, req.(*CreateTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_RenameTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).RenameTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_RenameTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).RenameTag(ctx, req.(*RenameTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).DeleteTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_DeleteTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).DeleteTag(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_MergeTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).MergeTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_MergeTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).MergeTags(ctx, req.(*MergeTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_ListProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ListProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ListProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ListProjects(ctx, req.(*ListProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_GetProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).GetProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_GetProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).GetProject(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_CreateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Project)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).CreateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_CreateProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).CreateProject(ctx, req.(*Project))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_UpdateProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Project)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).UpdateProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_UpdateProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).UpdateProject(ctx, req.(*Project))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_ArchiveProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ArchiveProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ArchiveProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ArchiveProject(ctx, req.(*ArchiveProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_DeleteProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).DeleteProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_DeleteProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).DeleteProject(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchedulerService_GetProjectStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).GetProjectStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_GetProjectStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).GetProjectStats(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *schedulerServiceClient) AddTask(ctx context.Context, in *Task, opts ...grpc.CallOption) (*AddTaskResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(AddTaskResponse)
-	err := c.cc.Invoke(ctx, SchedulerService_AddTask_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _SchedulerService_ListSubtasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ListSubtasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ListSubtasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ListSubtasks(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *schedulerServiceClient) UpdateDate(ctx context.Context, in *UpdateDateRequest, opts ...grpc.CallOption) (*EmptyResponse, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(EmptyResponse)
-	err := c.cc.Invoke(ctx, SchedulerService_UpdateDate_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _SchedulerService_CheckItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckItemRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).CheckItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_CheckItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).CheckItem(ctx, req.(*CheckItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// SchedulerServiceServer is the server API for SchedulerService service.
-// All implementations must embed UnimplementedSchedulerServiceServer
-// for forward compatibility.
-type SchedulerServiceServer interface {
-	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
-	GetTask(context.Context, *IDRequest) (*GetTaskResponse, error)
-	UpdateTask(context.Context, *UpdateTaskRequest) (*EmptyResponse, error)
-	DeleteTask(context.Context, *IDRequest) (*EmptyResponse, error)
-	DoneTask(context.Context, *IDRequest) (*EmptyResponse, error)
-	NextDate(context.Context, *NextDateRequest) (*NextDateResponse, error)
-	AddTask(context.Context, *Task) (*AddTaskResponse, error)
-	UpdateDate(context.Context, *UpdateDateRequest) (*EmptyResponse, error)
-	mustEmbedUnimplementedSchedulerServiceServer()
+func _SchedulerService_AddDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).AddDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_AddDependency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).AddDependency(ctx, req.(*DependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedSchedulerServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedSchedulerServiceServer struct{}
-
-func (UnimplementedSchedulerServiceServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
-}
-func (UnimplementedSchedulerServiceServer) GetTask(context.Context, *IDRequest) (*GetTaskResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTask not implemented")
-}
-func (UnimplementedSchedulerServiceServer) UpdateTask(context.Context, *UpdateTaskRequest) (*EmptyResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateTask not implemented")
-}
-func (UnimplementedSchedulerServiceServer) DeleteTask(context.Context, *IDRequest) (*EmptyResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteTask not implemented")
-}
-func (UnimplementedSchedulerServiceServer) DoneTask(context.Context, *IDRequest) (*EmptyResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DoneTask not implemented")
-}
-func (UnimplementedSchedulerServiceServer) NextDate(context.Context, *NextDateRequest) (*NextDateResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method NextDate not implemented")
-}
-func (UnimplementedSchedulerServiceServer) AddTask(context.Context, *Task) (*AddTaskResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddTask not implemented")
-}
-func (UnimplementedSchedulerServiceServer) UpdateDate(context.Context, *UpdateDateRequest) (*EmptyResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateDate not implemented")
+func _SchedulerService_RemoveDependency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DependencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).RemoveDependency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_RemoveDependency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).RemoveDependency(ctx, req.(*DependencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedSchedulerServiceServer) mustEmbedUnimplementedSchedulerServiceServer() {}
-func (UnimplementedSchedulerServiceServer) testEmbeddedByValue()                          {}
 
-// UnsafeSchedulerServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to SchedulerServiceServer will
-// result in compilation errors.
-type UnsafeSchedulerServiceServer interface {
-	mustEmbedUnimplementedSchedulerServiceServer()
+func _SchedulerService_SetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).SetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_SetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).SetStatus(ctx, req.(*SetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterSchedulerServiceServer(s grpc.ServiceRegistrar, srv SchedulerServiceServer) {
-	// If the following call pancis, it indicates UnimplementedSchedulerServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _SchedulerService_ListStatusChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&SchedulerService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(SchedulerServiceServer).ListStatusChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchedulerService_ListStatusChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchedulerServiceServer).ListStatusChanges(ctx, req.(*IDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _SchedulerService_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListTasksRequest)
+func _SchedulerService_ListRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IDRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SchedulerServiceServer).ListTasks(ctx, in)
+		return srv.(SchedulerServiceServer).ListRevisions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SchedulerService_ListTasks_FullMethodName,
+		FullMethod: SchedulerService_ListRevisions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SchedulerServiceServer).ListTasks(ctx, req.(*ListTasksRequest))
+		return srv.(SchedulerServiceServer).ListRevisions(ctx, req.(*IDRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SchedulerService_GetTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(IDRequest)
+func _SchedulerService_GetRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevisionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SchedulerServiceServer).GetTask(ctx, in)
+		return srv.(SchedulerServiceServer).GetRevision(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SchedulerService_GetTask_FullMethodName,
+		FullMethod: SchedulerService_GetRevision_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SchedulerServiceServer).GetTask(ctx, req.(*IDRequest))
+		return srv.(SchedulerServiceServer).GetRevision(ctx, req.(*RevisionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SchedulerService_UpdateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateTaskRequest)
+func _SchedulerService_RevertTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevisionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SchedulerServiceServer).UpdateTask(ctx, in)
+		return srv.(SchedulerServiceServer).RevertTask(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SchedulerService_UpdateTask_FullMethodName,
+		FullMethod: SchedulerService_RevertTask_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SchedulerServiceServer).UpdateTask(ctx, req.(*UpdateTaskRequest))
+		return srv.(SchedulerServiceServer).RevertTask(ctx, req.(*RevisionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SchedulerService_DeleteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _SchedulerService_ListAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IDRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SchedulerServiceServer).DeleteTask(ctx, in)
+		return srv.(SchedulerServiceServer).ListAttachments(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SchedulerService_DeleteTask_FullMethodName,
+		FullMethod: SchedulerService_ListAttachments_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SchedulerServiceServer).DeleteTask(ctx, req.(*IDRequest))
+		return srv.(SchedulerServiceServer).ListAttachments(ctx, req.(*IDRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SchedulerService_DoneTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _SchedulerService_UploadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SchedulerServiceServer).UploadAttachment(&grpc.GenericServerStream[UploadAttachmentRequest, Attachment]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SchedulerService_UploadAttachmentServer = grpc.ClientStreamingServer[UploadAttachmentRequest, Attachment]
+
+func _SchedulerService_DownloadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(IDRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SchedulerServiceServer).DownloadAttachment(m, &grpc.GenericServerStream[IDRequest, DownloadAttachmentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SchedulerService_DownloadAttachmentServer = grpc.ServerStreamingServer[DownloadAttachmentResponse]
+
+func _SchedulerService_DeleteAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(IDRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SchedulerServiceServer).DoneTask(ctx, in)
+		return srv.(SchedulerServiceServer).DeleteAttachment(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SchedulerService_DoneTask_FullMethodName,
+		FullMethod: SchedulerService_DeleteAttachment_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SchedulerServiceServer).DoneTask(ctx, req.(*IDRequest))
+		return srv.(SchedulerServiceServer).DeleteAttachment(ctx, req.(*IDRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SchedulerService_NextDate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NextDateRequest)
+func _SchedulerService_GetClock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SchedulerServiceServer).NextDate(ctx, in)
+		return srv.(SchedulerServiceServer).GetClock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SchedulerService_NextDate_FullMethodName,
+		FullMethod: SchedulerService_GetClock_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SchedulerServiceServer).NextDate(ctx, req.(*NextDateRequest))
+		return srv.(SchedulerServiceServer).GetClock(ctx, req.(*EmptyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SchedulerService_AddTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Task)
+func _SchedulerService_SetClock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetClockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SchedulerServiceServer).AddTask(ctx, in)
+		return srv.(SchedulerServiceServer).SetClock(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SchedulerService_AddTask_FullMethodName,
+		FullMethod: SchedulerService_SetClock_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SchedulerServiceServer).AddTask(ctx, req.(*Task))
+		return srv.(SchedulerServiceServer).SetClock(ctx, req.(*SetClockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SchedulerService_UpdateDate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateDateRequest)
+func _SchedulerService_ListAuditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAuditEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SchedulerServiceServer).UpdateDate(ctx, in)
+		return srv.(SchedulerServiceServer).ListAuditEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SchedulerService_UpdateDate_FullMethodName,
+		FullMethod: SchedulerService_ListAuditEvents_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SchedulerServiceServer).UpdateDate(ctx, req.(*UpdateDateRequest))
+		return srv.(SchedulerServiceServer).ListAuditEvents(ctx, req.(*ListAuditEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -381,7 +1435,134 @@ var SchedulerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateDate",
 			Handler:    _SchedulerService_UpdateDate_Handler,
 		},
+		{
+			MethodName: "SnoozeTask",
+			Handler:    _SchedulerService_SnoozeTask_Handler,
+		},
+		{
+			MethodName: "SkipOccurrence",
+			Handler:    _SchedulerService_SkipOccurrence_Handler,
+		},
+		{
+			MethodName: "ListMissed",
+			Handler:    _SchedulerService_ListMissed_Handler,
+		},
+		{
+			MethodName: "ListTags",
+			Handler:    _SchedulerService_ListTags_Handler,
+		},
+		{
+			MethodName: "CreateTag",
+			Handler:    _SchedulerService_CreateTag_Handler,
+		},
+		{
+			MethodName: "RenameTag",
+			Handler:    _SchedulerService_RenameTag_Handler,
+		},
+		{
+			MethodName: "DeleteTag",
+			Handler:    _SchedulerService_DeleteTag_Handler,
+		},
+		{
+			MethodName: "MergeTags",
+			Handler:    _SchedulerService_MergeTags_Handler,
+		},
+		{
+			MethodName: "ListProjects",
+			Handler:    _SchedulerService_ListProjects_Handler,
+		},
+		{
+			MethodName: "GetProject",
+			Handler:    _SchedulerService_GetProject_Handler,
+		},
+		{
+			MethodName: "CreateProject",
+			Handler:    _SchedulerService_CreateProject_Handler,
+		},
+		{
+			MethodName: "UpdateProject",
+			Handler:    _SchedulerService_UpdateProject_Handler,
+		},
+		{
+			MethodName: "ArchiveProject",
+			Handler:    _SchedulerService_ArchiveProject_Handler,
+		},
+		{
+			MethodName: "DeleteProject",
+			Handler:    _SchedulerService_DeleteProject_Handler,
+		},
+		{
+			MethodName: "GetProjectStats",
+			Handler:    _SchedulerService_GetProjectStats_Handler,
+		},
+		{
+			MethodName: "ListSubtasks",
+			Handler:    _SchedulerService_ListSubtasks_Handler,
+		},
+		{
+			MethodName: "CheckItem",
+			Handler:    _SchedulerService_CheckItem_Handler,
+		},
+		{
+			MethodName: "AddDependency",
+			Handler:    _SchedulerService_AddDependency_Handler,
+		},
+		{
+			MethodName: "RemoveDependency",
+			Handler:    _SchedulerService_RemoveDependency_Handler,
+		},
+		{
+			MethodName: "SetStatus",
+			Handler:    _SchedulerService_SetStatus_Handler,
+		},
+		{
+			MethodName: "ListStatusChanges",
+			Handler:    _SchedulerService_ListStatusChanges_Handler,
+		},
+		{
+			MethodName: "ListRevisions",
+			Handler:    _SchedulerService_ListRevisions_Handler,
+		},
+		{
+			MethodName: "GetRevision",
+			Handler:    _SchedulerService_GetRevision_Handler,
+		},
+		{
+			MethodName: "RevertTask",
+			Handler:    _SchedulerService_RevertTask_Handler,
+		},
+		{
+			MethodName: "ListAttachments",
+			Handler:    _SchedulerService_ListAttachments_Handler,
+		},
+		{
+			MethodName: "DeleteAttachment",
+			Handler:    _SchedulerService_DeleteAttachment_Handler,
+		},
+		{
+			MethodName: "GetClock",
+			Handler:    _SchedulerService_GetClock_Handler,
+		},
+		{
+			MethodName: "SetClock",
+			Handler:    _SchedulerService_SetClock_Handler,
+		},
+		{
+			MethodName: "ListAuditEvents",
+			Handler:    _SchedulerService_ListAuditEvents_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadAttachment",
+			Handler:       _SchedulerService_UploadAttachment_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadAttachment",
+			Handler:       _SchedulerService_DownloadAttachment_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "task.proto",
 }
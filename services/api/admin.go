@@ -0,0 +1,69 @@
+package api
+
+import (
+	"crypto/subtle"
+	"log"
+	"net/http"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	"google.golang.org/grpc/metadata"
+)
+
+// заголовок и ключ метаданных с токеном администратора
+const (
+	adminTokenHeader = "X-Admin-Token"
+	adminTokenKey    = "x-admin-token"
+)
+
+// isAdmin проверяет токен администратора, без токена в конфиге админка отключена
+func (app *AppAPI) isAdmin(r *http.Request) bool {
+	token := r.Header.Get(adminTokenHeader)
+	return app.conf.AdminToken != "" &&
+		subtle.ConstantTimeCompare([]byte(token), []byte(app.conf.AdminToken)) == 1
+}
+
+// clockHandler обработчик /api/admin/clock — замороженное время для стенда.
+// GET — текущее время, PUT ?time=YYYYMMDD|RFC3339 — заморозить, DELETE — разморозить.
+// Часы есть только у db-сервиса: API текущую дату не считает, поэтому время
+// одинаково для всех реплик API, через какую бы из них его ни заморозили.
+func (app *AppAPI) clockHandler(w http.ResponseWriter, r *http.Request) {
+	if !app.isAdmin(r) {
+		writeError(w, r, apperrors.ErrAdminToken)
+		return
+	}
+
+	ctx := metadata.AppendToOutgoingContext(r.Context(), adminTokenKey, app.conf.AdminToken)
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	var (
+		resp *pb.ClockResponse
+		err  error
+	)
+	switch r.Method {
+	case http.MethodGet:
+		resp, err = client.GetClock(ctx, &pb.EmptyRequest{})
+	case http.MethodPut:
+		frozen := r.URL.Query().Get("time")
+		if frozen == "" {
+			writeError(w, r, apperrors.ErrInvalidParameter.With("name", "time"))
+			return
+		}
+		resp, err = client.SetClock(ctx, &pb.SetClockRequest{FrozenTime: frozen})
+	case http.MethodDelete:
+		resp, err = client.SetClock(ctx, &pb.SetClockRequest{})
+	default:
+		writeMethodNotAllowed(w, r)
+		return
+	}
+	if err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, err)
+		return
+	}
+
+	WriteJson(w, http.StatusOK, map[string]interface{}{
+		"now":    resp.Now,
+		"frozen": resp.Frozen,
+	})
+}
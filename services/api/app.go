@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 
+	cm "github.com/Vasya-lis/firstWorkWithgRPC/common"
 	cfg "github.com/Vasya-lis/firstWorkWithgRPC/config"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -15,7 +16,7 @@ type AppAPI struct {
 	conf    *cfg.Config      // env
 	conn    *grpc.ClientConn // для соединения с db
 	server  *http.Server     // вебсервер
-	context context.Context
+	context context.Context  // фоновый контекст сервиса, запросы к db-сервису идут с контекстом HTTP запроса
 }
 
 func NewAppApi() (*AppAPI, error) {
@@ -23,16 +24,38 @@ func NewAppApi() (*AppAPI, error) {
 	if err != nil {
 		return nil, fmt.Errorf("configuration failed: %w", err)
 	}
+	if err := cm.SetDefaultLocation(config.DefaultTZ); err != nil {
+		return nil, fmt.Errorf("configuration failed: %w", err)
+	}
+	if err := cm.InitCalendar(config.CalendarPath); err != nil {
+		return nil, fmt.Errorf("calendar init failed: %w", err)
+	}
+
+	// дедлайны и повторы вызовов db-сервиса
+	serviceConfig, err := buildServiceConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("configuration failed: %w", err)
+	}
+
+	// заголовки автора и id запроса принимаются только от этих прокси
+	trusted, err := parseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		return nil, fmt.Errorf("configuration failed: %w", err)
+	}
 
 	// Подключение к gRPC серверу
-	conn, err := grpc.NewClient(config.DBServiceAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient(config.DBServiceAddress,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultServiceConfig(serviceConfig),
+	)
 	if err != nil {
 		log.Printf("Failed to connect to gRPC server: %v", err)
 		return nil, fmt.Errorf("failed to connect to gRPC server: %w", err)
 	}
 
 	server := &http.Server{
-		Addr: ":" + config.TodoPort,
+		Addr:    ":" + config.TodoPort,
+		Handler: requestMeta(trusted, http.DefaultServeMux),
 	}
 
 	app := &AppAPI{
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// размер части содержимого в потоке загрузки
+const uploadChunkSize = 64 << 10
+
+// attachmentsHandler обработчик /api/task/attachments?id=: GET — вложения задачи,
+// POST — загрузка файлов формы multipart/form-data из полей file, возвращает созданные вложения
+func (app *AppAPI) attachmentsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := GetIDFromQuery(w, r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	switch r.Method {
+	case http.MethodGet:
+		resp, err := client.ListAttachments(r.Context(), &pb.IDRequest{Id: int32(id)})
+		if err != nil {
+			log.Println("error: ", err)
+			writeError(w, r, err)
+			return
+		}
+
+		attachments := []*md.Attachment{}
+		for _, a := range resp.Attachments {
+			attachments = append(attachments, attachmentFromProto(a))
+		}
+		WriteJson(w, http.StatusOK, map[string]interface{}{"attachments": attachments})
+	case http.MethodPost:
+		uploadAttachments(w, r, client, id)
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// uploadAttachments передает файлы формы в db-сервис потоком, не читая их в память целиком.
+// Файлы сохраняются по одному: при ошибке уже загруженные остаются у задачи.
+func uploadAttachments(w http.ResponseWriter, r *http.Request, client pb.SchedulerServiceClient, taskID int) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		writeError(w, r, apperrors.ErrAttachmentRequired)
+		return
+	}
+
+	attachments := []*md.Attachment{}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			writeError(w, r, fmt.Errorf("%w: %w", apperrors.ErrInvalidParameter.With("name", "file"), err))
+			return
+		}
+		if part.FormName() != "file" || part.FileName() == "" {
+			part.Close()
+			continue
+		}
+
+		attachment, err := uploadPart(r.Context(), client, taskID, part)
+		part.Close()
+		if err != nil {
+			log.Println("error: ", err)
+			writeError(w, r, err)
+			return
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if len(attachments) == 0 {
+		writeError(w, r, apperrors.ErrAttachmentRequired)
+		return
+	}
+	WriteJson(w, http.StatusCreated, map[string]interface{}{"attachments": attachments})
+}
+
+// uploadPart отправляет один файл в UploadAttachment. Ошибка чтения формы отменяет
+// вызов, поэтому db-сервис не сохраняет обрезанный файл.
+func uploadPart(ctx context.Context, client pb.SchedulerServiceClient, taskID int, part *multipart.Part) (*md.Attachment, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.UploadAttachment(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// io.EOF от Send значит, что сервер завершил поток, его ошибку возвращает CloseAndRecv
+	send := func(req *pb.UploadAttachmentRequest) error {
+		err := stream.Send(req)
+		if errors.Is(err, io.EOF) {
+			_, err = stream.CloseAndRecv()
+		}
+		return err
+	}
+
+	err = send(&pb.UploadAttachmentRequest{Data: &pb.UploadAttachmentRequest_Info{Info: &pb.AttachmentInfo{
+		TaskId:      int32(taskID),
+		Name:        part.FileName(),
+		ContentType: part.Header.Get("Content-Type"),
+	}}})
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	for {
+		n, err := io.ReadFull(part, buf)
+		if n > 0 {
+			if err := send(&pb.UploadAttachmentRequest{Data: &pb.UploadAttachmentRequest_Chunk{Chunk: buf[:n]}}); err != nil {
+				return nil, err
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", apperrors.ErrInvalidParameter.With("name", "file"), err)
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+	return attachmentFromProto(resp), nil
+}
+
+// attachmentHandler обработчик /api/task/attachment?id=, id — вложения:
+// GET — содержимое файла, DELETE — удалить вложение
+func (app *AppAPI) attachmentHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := GetIDFromQuery(w, r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	switch r.Method {
+	case http.MethodGet:
+		downloadAttachment(w, r, client, id)
+	case http.MethodDelete:
+		if _, err := client.DeleteAttachment(r.Context(), &pb.IDRequest{Id: int32(id)}); err != nil {
+			log.Println("error: ", err)
+			writeError(w, r, err)
+			return
+		}
+		WriteJson(w, http.StatusOK, map[string]interface{}{})
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// downloadAttachment передает содержимое из DownloadAttachment в ответ по частям.
+// Ошибка после начала ответа только логируется: клиент увидит обрыв по Content-Length.
+func downloadAttachment(w http.ResponseWriter, r *http.Request, client pb.SchedulerServiceClient, id int) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	stream, err := client.DownloadAttachment(ctx, &pb.IDRequest{Id: int32(id)})
+	if err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, err)
+		return
+	}
+	// ошибки db-сервиса, например ATTACHMENT_NOT_FOUND, приходят с первым сообщением
+	first, err := stream.Recv()
+	if err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, err)
+		return
+	}
+	info := first.GetInfo()
+	if info == nil {
+		log.Println("error: ", "download stream without attachment info")
+		writeError(w, r, apperrors.ErrInternal)
+		return
+	}
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": info.Name})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	// файлы загружают пользователи, поэтому браузер не угадывает тип и не открывает их на странице
+	w.Header().Set("Content-Type", info.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(info.Size, 10))
+	w.Header().Set("Content-Disposition", disposition)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("ETag", `"`+info.Sha256+`"`)
+	w.WriteHeader(http.StatusOK)
+
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			log.Println("error: ", err)
+			return
+		}
+		if _, err := w.Write(msg.GetChunk()); err != nil {
+			return
+		}
+	}
+}
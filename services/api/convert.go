@@ -0,0 +1,203 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// taskToProto переводит модель задачи в прото буф
+func taskToProto(t *md.Task) *pb.Task {
+	return &pb.Task{
+		Id:         int32(t.ID),
+		Date:       t.Date,
+		Title:      t.Title,
+		Comment:    t.Comment,
+		Repeat:     t.Repeat,
+		Time:       t.Time,
+		Tz:         t.TZ,
+		Remaining:  int32(t.Remaining),
+		RepeatMode: t.RepeatMode,
+		Anchor:     t.Anchor,
+		Exceptions: t.Exceptions,
+		CatchUp:    t.CatchUp,
+		Priority:   pb.Priority(t.Priority),
+		Status:     pb.Status(t.Status),
+		Tags:       t.Tags,
+		ProjectId:  refToProto(t.ProjectID),
+		ParentId:   refToProto(t.ParentID),
+		Checklist:  checklistToProto(t.Checklist),
+		DependsOn:  idsToProto(t.DependsOn),
+		Blocked:    t.Blocked,
+		CreatedAt:  timeToProto(t.CreatedAt),
+		UpdatedAt:  timeToProto(t.UpdatedAt),
+	}
+}
+
+// taskFromProto переводит прото буф в модель задачи
+func taskFromProto(t *pb.Task) *md.Task {
+	return &md.Task{
+		ID:         int(t.Id),
+		Date:       t.Date,
+		Title:      t.Title,
+		Comment:    t.Comment,
+		Repeat:     t.Repeat,
+		Time:       t.Time,
+		TZ:         t.Tz,
+		Remaining:  int(t.Remaining),
+		RepeatMode: t.RepeatMode,
+		Anchor:     t.Anchor,
+		Exceptions: t.Exceptions,
+		CatchUp:    t.CatchUp,
+		Priority:   md.Priority(t.Priority),
+		Status:     md.Status(t.Status),
+		Tags:       t.Tags,
+		ProjectID:  refFromProto(t.ProjectId),
+		ParentID:   refFromProto(t.ParentId),
+		Checklist:  checklistFromProto(t.Checklist),
+		DependsOn:  idsFromProto(t.DependsOn),
+		Blocked:    t.Blocked,
+		CreatedAt:  timeFromProto(t.CreatedAt),
+		UpdatedAt:  timeFromProto(t.UpdatedAt),
+	}
+}
+
+// tagFromProto переводит прото буф метки в модель
+func tagFromProto(t *pb.Tag) *md.Tag {
+	return &md.Tag{
+		ID:        int(t.Id),
+		Name:      t.Name,
+		TaskCount: int(t.TaskCount),
+	}
+}
+
+// refToProto переводит необязательную ссылку (проект, родитель) в id, 0 — ссылки нет
+func refToProto(id *int) int32 {
+	if id == nil {
+		return 0
+	}
+	return int32(*id)
+}
+
+// refFromProto переводит id в необязательную ссылку, 0 — ссылки нет
+func refFromProto(id int32) *int {
+	if id == 0 {
+		return nil
+	}
+	v := int(id)
+	return &v
+}
+
+// projectFromProto переводит прото буф проекта в модель
+func projectFromProto(p *pb.Project) *md.Project {
+	createdAt, _ := time.Parse(time.RFC3339, p.CreatedAt)
+	return &md.Project{
+		ID:          int(p.Id),
+		Name:        p.Name,
+		Description: p.Description,
+		Archived:    p.Archived,
+		CreatedAt:   createdAt,
+		TaskCount:   int(p.TaskCount),
+	}
+}
+
+// projectStatsFromProto переводит прото буф сводки по проекту в модель
+func projectStatsFromProto(s *pb.ProjectStats) *md.ProjectStats {
+	return &md.ProjectStats{
+		ProjectID:    int(s.ProjectId),
+		Total:        int(s.Total),
+		Overdue:      int(s.Overdue),
+		DueToday:     int(s.DueToday),
+		Upcoming:     int(s.Upcoming),
+		HighPriority: int(s.HighPriority),
+		Repeating:    int(s.Repeating),
+		Cancelled:    int(s.Cancelled),
+	}
+}
+
+// checklistToProto переводит чек-лист в прото буф
+func checklistToProto(c md.Checklist) []*pb.ChecklistItem {
+	var items []*pb.ChecklistItem
+	for _, item := range c {
+		items = append(items, &pb.ChecklistItem{Title: item.Title, Done: item.Done})
+	}
+	return items
+}
+
+// checklistFromProto переводит прото буф в чек-лист
+func checklistFromProto(items []*pb.ChecklistItem) md.Checklist {
+	var c md.Checklist
+	for _, item := range items {
+		c = append(c, md.ChecklistItem{Title: item.Title, Done: item.Done})
+	}
+	return c
+}
+
+// idsToProto переводит список id в прото буф
+func idsToProto(ids []int) []int32 {
+	var out []int32
+	for _, id := range ids {
+		out = append(out, int32(id))
+	}
+	return out
+}
+
+// idsFromProto переводит список id из прото буфа
+func idsFromProto(ids []int32) []int {
+	var out []int
+	for _, id := range ids {
+		out = append(out, int(id))
+	}
+	return out
+}
+
+// timeToProto переводит время в RFC 3339, нулевое — пустая строка
+func timeToProto(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// timeFromProto разбирает время в RFC 3339, пустое или неверное — нулевое
+func timeFromProto(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+// revisionFromProto переводит прото буф версии задачи в модель
+func revisionFromProto(r *pb.TaskRevision) *md.TaskRevision {
+	revision := &md.TaskRevision{
+		ID:        int(r.Id),
+		TaskID:    int(r.TaskId),
+		Number:    int(r.Number),
+		CreatedAt: timeFromProto(r.CreatedAt),
+		Changes:   []md.FieldChange{},
+	}
+	if r.Snapshot != nil {
+		revision.Snapshot = taskFromProto(r.Snapshot).Snapshot()
+	}
+	for _, c := range r.Changes {
+		revision.Changes = append(revision.Changes, md.FieldChange{
+			Field: c.Field,
+			Old:   json.RawMessage(c.Old),
+			New:   json.RawMessage(c.New),
+		})
+	}
+	return revision
+}
+
+// attachmentFromProto переводит прото буф вложения в модель
+func attachmentFromProto(a *pb.Attachment) *md.Attachment {
+	return &md.Attachment{
+		ID:          int(a.Id),
+		TaskID:      int(a.TaskId),
+		Name:        a.Name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		SHA256:      a.Sha256,
+		CreatedAt:   timeFromProto(a.CreatedAt),
+	}
+}
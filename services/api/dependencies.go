@@ -0,0 +1,47 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+)
+
+// dependenciesHandler обработчик /api/task/dependencies?id=&depends_on=N:
+// POST — задача id ждет выполнения задачи N, DELETE — убрать зависимость.
+// Возвращает задачу id
+func (app *AppAPI) dependenciesHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := GetIDFromQuery(w, r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	dependsOn, err := strconv.Atoi(r.URL.Query().Get("depends_on"))
+	if err != nil || dependsOn <= 0 {
+		writeError(w, r, apperrors.ErrInvalidParameter.With("name", "depends_on"))
+		return
+	}
+
+	client := pb.NewSchedulerServiceClient(app.conn)
+	req := &pb.DependencyRequest{TaskId: int32(id), DependsOnId: int32(dependsOn)}
+
+	var task *pb.Task
+	switch r.Method {
+	case http.MethodPost:
+		task, err = client.AddDependency(r.Context(), req)
+	case http.MethodDelete:
+		task, err = client.RemoveDependency(r.Context(), req)
+	default:
+		writeMethodNotAllowed(w, r)
+		return
+	}
+	if err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, err)
+		return
+	}
+
+	WriteJson(w, http.StatusOK, taskFromProto(task))
+}
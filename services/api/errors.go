@@ -0,0 +1,176 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// тип ответа с ошибкой по RFC 7807
+const problemContentType = "application/problem+json; charset=UTF-8"
+
+// problem тело ответа с ошибкой (RFC 7807), code — стабильный машиночитаемый код
+type problem struct {
+	Type     string           `json:"type"`
+	Title    string           `json:"title"`
+	Status   int              `json:"status"`
+	Detail   string           `json:"detail,omitempty"`
+	Instance string           `json:"instance,omitempty"`
+	Code     string           `json:"code"`
+	Errors   []fieldViolation `json:"errors,omitempty"`
+}
+
+// fieldViolation нарушение ограничения поля в ответе API
+type fieldViolation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// grpcError статус HTTP и код ошибки по умолчанию для кода gRPC
+type grpcError struct {
+	status int
+	code   string
+}
+
+// grpcErrors соответствие кодов gRPC статусам HTTP; код из ErrorInfo db-сервиса
+// имеет приоритет над кодом по умолчанию
+var grpcErrors = map[codes.Code]grpcError{
+	codes.InvalidArgument:    {http.StatusBadRequest, "INVALID_ARGUMENT"},
+	codes.OutOfRange:         {http.StatusBadRequest, "OUT_OF_RANGE"},
+	codes.FailedPrecondition: {http.StatusConflict, "FAILED_PRECONDITION"},
+	codes.Unauthenticated:    {http.StatusUnauthorized, "UNAUTHENTICATED"},
+	codes.PermissionDenied:   {http.StatusForbidden, "PERMISSION_DENIED"},
+	codes.NotFound:           {http.StatusNotFound, "NOT_FOUND"},
+	codes.AlreadyExists:      {http.StatusConflict, "ALREADY_EXISTS"},
+	codes.Aborted:            {http.StatusConflict, "ABORTED"},
+	codes.ResourceExhausted:  {http.StatusTooManyRequests, "RESOURCE_EXHAUSTED"},
+	codes.Canceled:           {499, "CANCELED"}, // клиент закрыл запрос, как в nginx
+	codes.DeadlineExceeded:   {http.StatusGatewayTimeout, "DEADLINE_EXCEEDED"},
+	codes.Unimplemented:      {http.StatusNotImplemented, "UNIMPLEMENTED"},
+	codes.Unavailable:        {http.StatusServiceUnavailable, "UNAVAILABLE"},
+	codes.Internal:           {http.StatusInternalServerError, apperrors.CodeInternal},
+	codes.DataLoss:           {http.StatusInternalServerError, apperrors.CodeInternal},
+	codes.Unknown:            {http.StatusInternalServerError, apperrors.CodeInternal},
+}
+
+// kindStatuses соответствие класса локальной ошибки статусу HTTP
+var kindStatuses = map[apperrors.Kind]int{
+	apperrors.KindInvalid:   http.StatusBadRequest,
+	apperrors.KindNotFound:  http.StatusNotFound,
+	apperrors.KindConflict:  http.StatusConflict,
+	apperrors.KindForbidden: http.StatusForbidden,
+	apperrors.KindInternal:  http.StatusInternalServerError,
+
+	apperrors.KindMethodNotAllowed: http.StatusMethodNotAllowed,
+}
+
+// writeProblem пишет ответ application/problem+json
+func writeProblem(w http.ResponseWriter, r *http.Request, lang string, p problem) {
+	if p.Type == "" {
+		p.Type = "urn:scheduler:error:" + strings.ToLower(p.Code)
+	}
+	p.Title = http.StatusText(p.Status)
+	if p.Title == "" {
+		p.Title = "Client Closed Request"
+	}
+	p.Instance = r.URL.Path
+	w.Header().Set("Content-Type", problemContentType)
+	w.Header().Set("Content-Language", lang)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// writeMethodNotAllowed отвечает 405 на неподдерживаемый метод
+func writeMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, r, apperrors.ErrMethodNotAllowed)
+}
+
+// writeError единый слой отображения ошибок в HTTP: ошибки db-сервиса
+// разбираются по коду gRPC и деталям статуса, локальные — по apperrors.
+// Сообщения берутся из каталога на языке из Accept-Language по стабильному коду.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	lang := requestLang(r)
+
+	var p problem
+	if st, ok := status.FromError(err); ok {
+		p = statusProblem(st, lang)
+	} else if fields := apperrors.FieldErrors(err); len(fields) > 0 {
+		names := make([]string, 0, len(fields))
+		for _, f := range fields {
+			names = append(names, f.Field)
+			p.Errors = append(p.Errors, fieldViolation{Field: f.Field, Message: apperrors.Localize(f.Err, lang)})
+		}
+		invalid := apperrors.ErrInvalidFields.With("fields", strings.Join(names, ", "))
+		p.Status = http.StatusBadRequest
+		p.Code = invalid.Code
+		p.Detail = apperrors.Localize(invalid, lang)
+	} else {
+		p = problem{
+			Status: kindStatuses[apperrors.KindOf(err)],
+			Code:   apperrors.Code(err),
+			Detail: apperrors.Localize(err, lang),
+		}
+	}
+	writeProblem(w, r, lang, p)
+}
+
+// statusProblem разбирает статус db-сервиса: код и параметры из ErrorInfo,
+// нарушения полей из BadRequest. Без ErrorInfo код выводится из кода gRPC.
+func statusProblem(st *status.Status, lang string) problem {
+	ge, known := grpcErrors[st.Code()]
+	if !known {
+		ge = grpcErrors[codes.Internal]
+	}
+	p := problem{Status: ge.status, Code: ge.code}
+
+	var params map[string]string
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			p.Code = d.Reason
+			params = d.Metadata
+		case *errdetails.BadRequest:
+			violations = append(violations, d.FieldViolations...)
+		}
+	}
+
+	switch {
+	case apperrors.HasMessage(p.Code):
+		p.Detail = apperrors.Message(p.Code, lang, params)
+	case p.Status < http.StatusInternalServerError:
+		p.Detail = st.Message()
+	default:
+		// текст сбоя клиенту не отдаем
+		p.Detail = apperrors.Message(apperrors.CodeInternal, lang, nil)
+	}
+
+	for _, v := range violations {
+		msg := v.Description
+		if v.Reason != "" && apperrors.HasMessage(v.Reason) {
+			msg = apperrors.Message(v.Reason, lang, fieldParams(params, v.Field))
+		}
+		// тело запроса — сама задача, путь внутри UpdateTaskRequest не нужен
+		p.Errors = append(p.Errors, fieldViolation{
+			Field:   strings.TrimPrefix(v.Field, "task."),
+			Message: msg,
+		})
+	}
+	return p
+}
+
+// fieldParams выбирает из метаданных ErrorInfo параметры сообщения поля ("поле.параметр")
+func fieldParams(metadata map[string]string, field string) map[string]string {
+	params := make(map[string]string)
+	for k, v := range metadata {
+		if name, ok := strings.CutPrefix(k, field+"."); ok {
+			params[name] = v
+		}
+	}
+	return params
+}
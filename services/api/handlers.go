@@ -2,11 +2,15 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"slices"
+	"strconv"
 	"time"
 
-	cm "github.com/Vasya-lis/firstWorkWithgRPC/common"
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/common/validate"
 	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
 	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
 )
@@ -15,34 +19,23 @@ func (app *AppAPI) AddTaskHandler(w http.ResponseWriter, r *http.Request) {
 	var task md.Task
 	if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
 		log.Println("error: ", err)
-		WriteJson(w, http.StatusBadRequest, map[string]string{"error": "Invalid JSON"})
+		writeError(w, r, fmt.Errorf("%w: %w", apperrors.ErrInvalidJSON, err))
 		return
 	}
 
-	errResp := validateAdd(&task)
-	if errResp != nil {
-		WriteJson(w, http.StatusBadRequest, errResp)
-		return
-	}
-
-	if err := CheckDate(&task); err != nil {
-		log.Println("error: ", err)
-		WriteJson(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+	// ограничения полей из proto проверяем до вызова, нормализацию выполняет db-сервис
+	pbTask := taskToProto(&task)
+	if err := validate.Message(pbTask); err != nil {
+		writeError(w, r, err)
 		return
 	}
 
 	client := pb.NewSchedulerServiceClient(app.conn)
 
-	resp, err := client.AddTask(app.context, &pb.Task{
-		Title:   task.Title,
-		Date:    task.Date,
-		Comment: task.Comment,
-		Repeat:  task.Repeat,
-	})
+	resp, err := client.AddTask(r.Context(), pbTask)
 	if err != nil {
 		log.Println("error: ", err)
-		WriteJson(w, http.StatusInternalServerError, map[string]string{"error": "Failed to add task"})
-
+		writeError(w, r, err)
 		return
 	}
 	WriteJson(w, http.StatusOK, map[string]int{"id": int(resp.Id)})
@@ -53,63 +46,63 @@ func (app *AppAPI) GetTaskHandler(w http.ResponseWriter, r *http.Request) {
 
 	id, err := GetIDFromQuery(w, r)
 	if err != nil {
-		WriteJson(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		writeError(w, r, err)
 		return
 	}
 
 	client := pb.NewSchedulerServiceClient(app.conn)
 
-	task, err := client.GetTask(app.context, &pb.IDRequest{
+	task, err := client.GetTask(r.Context(), &pb.IDRequest{
 		Id: int32(id),
 	})
 	if err != nil {
 		log.Println("error: ", err)
-		WriteJson(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+		writeError(w, r, err)
 		return
 	}
-	WriteJson(w, http.StatusOK, &md.Task{
-		ID:      int(task.Task.Id),
-		Date:    task.Task.Date,
-		Title:   task.Task.Title,
-		Comment: task.Task.Comment,
-		Repeat:  task.Task.Repeat,
-	})
+	WriteJson(w, http.StatusOK, taskFromProto(task.Task))
 }
 
-// UpdateTaskHandler обработчик PUT /api/task
+// UpdateTaskHandler обработчик PUT /api/task. Меняются только поля, переданные в теле,
+// остальные остаются прежними
 func (app *AppAPI) UpdateTaskHandler(w http.ResponseWriter, r *http.Request) {
-	var task md.Task
-	err := json.NewDecoder(r.Body).Decode(&task)
+	var body map[string]json.RawMessage
+	err := json.NewDecoder(r.Body).Decode(&body)
 	if err != nil {
-		WriteJson(w, http.StatusBadRequest, map[string]string{"error": "ошибка десериализации JSON"})
+		log.Println("error: ", err)
+		writeError(w, r, fmt.Errorf("%w: %w", apperrors.ErrInvalidJSON, err))
 		return
 	}
-
-	errResp := validate(&task)
-	if errResp != nil {
-		WriteJson(w, http.StatusBadRequest, errResp)
+	// тело декодируется второй раз уже в задачу, ключи тела — переданные поля
+	raw, _ := json.Marshal(body)
+	var task md.Task
+	if err := json.Unmarshal(raw, &task); err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, fmt.Errorf("%w: %w", apperrors.ErrInvalidJSON, err))
 		return
 	}
+	fields := make([]string, 0, len(body))
+	for name := range body {
+		fields = append(fields, name)
+	}
+	slices.Sort(fields)
 
-	err = CheckDate(&task)
-	if err != nil {
-		WriteJson(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+	// ограничения переданных полей из proto проверяем до вызова, нормализацию выполняет db-сервис
+	pbTask := taskToProto(&task)
+	if err := validate.Partial(pbTask, "", fields); err != nil {
+		writeError(w, r, err)
 		return
 	}
 
 	client := pb.NewSchedulerServiceClient(app.conn)
 
-	_, err = client.UpdateTask(app.context, &pb.UpdateTaskRequest{
-		Task: &pb.Task{
-			Id:      int32(task.ID),
-			Title:   task.Title,
-			Date:    task.Date,
-			Comment: task.Comment,
-			Repeat:  task.Repeat,
-		},
+	_, err = client.UpdateTask(r.Context(), &pb.UpdateTaskRequest{
+		Task:   pbTask,
+		Fields: fields,
 	})
 	if err != nil {
-		WriteJson(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		log.Println("error: ", err)
+		writeError(w, r, err)
 		return
 	}
 
@@ -120,18 +113,18 @@ func (app *AppAPI) UpdateTaskHandler(w http.ResponseWriter, r *http.Request) {
 func (app *AppAPI) DeleteTaskHandler(w http.ResponseWriter, r *http.Request) {
 	id, err := GetIDFromQuery(w, r)
 	if err != nil {
-		WriteJson(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		writeError(w, r, err)
 		return
 	}
 
 	client := pb.NewSchedulerServiceClient(app.conn)
 
-	_, err = client.DeleteTask(app.context, &pb.IDRequest{
+	_, err = client.DeleteTask(r.Context(), &pb.IDRequest{
 		Id: int32(id),
 	})
 	if err != nil {
 		log.Println("error: ", err)
-		WriteJson(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		writeError(w, r, err)
 		return
 	}
 
@@ -142,49 +135,136 @@ func (app *AppAPI) doneTaskHandler(w http.ResponseWriter, r *http.Request) {
 
 	id, err := GetIDFromQuery(w, r)
 	if err != nil {
-		WriteJson(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		writeError(w, r, err)
+		return
+	}
+
+	// ?force=true — выполнить задачу, не дожидаясь ее зависимостей
+	var force bool
+	if v := r.URL.Query().Get("force"); v != "" {
+		force, err = strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, r, apperrors.ErrInvalidParameter.With("name", "force"))
+			return
+		}
+	}
+
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	// чтение, расчет следующей даты, перенос или удаление и учет пропущенных повторений
+	// db-сервис выполняет в одной транзакции
+	resp, err := client.DoneTask(r.Context(), &pb.DoneTaskRequest{
+		Id:    int32(id),
+		Force: force,
+	})
+	if err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, err)
+		return
+	}
+
+	WriteJson(w, http.StatusOK, DoneTaskResponse{Task: taskFromProto(resp.Task), Finished: resp.Finished})
+}
+
+// missedHandler обработчик GET /api/task/missed?id= — история пропущенных повторений
+func (app *AppAPI) missedHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	id, err := GetIDFromQuery(w, r)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
 	client := pb.NewSchedulerServiceClient(app.conn)
 
-	task, err := client.GetTask(app.context, &pb.IDRequest{
+	resp, err := client.ListMissed(r.Context(), &pb.IDRequest{
 		Id: int32(id),
 	})
 	if err != nil {
 		log.Println("error: ", err)
-		WriteJson(w, http.StatusNotFound, map[string]string{"error": err.Error()})
+		writeError(w, r, err)
 		return
 	}
 
-	if task.Task.Repeat == "" {
-		// Одноразовая задача — удаляем
-		_, err := client.DeleteTask(app.context, &pb.IDRequest{
-			Id: int32(id),
+	missed := []*md.MissedOccurrence{}
+	for _, m := range resp.Missed {
+		createdAt, _ := time.Parse(time.RFC3339, m.CreatedAt)
+		missed = append(missed, &md.MissedOccurrence{
+			ID:        int(m.Id),
+			TaskID:    int(m.TaskId),
+			Date:      m.Date,
+			Title:     m.Title,
+			CreatedAt: createdAt,
 		})
-		if err != nil {
-			log.Println("error: ", err)
-			WriteJson(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	WriteJson(w, http.StatusOK, map[string]interface{}{"missed": missed})
+}
+
+// snoozeTaskHandler обработчик POST /api/task/snooze?id=&days= или &date=
+func (app *AppAPI) snoozeTaskHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	id, err := GetIDFromQuery(w, r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	var days int
+	if daysStr := r.URL.Query().Get("days"); daysStr != "" {
+		days, err = strconv.Atoi(daysStr)
+		if err != nil || days <= 0 {
+			writeError(w, r, apperrors.ErrInvalidParameter.With("name", "days"))
 			return
 		}
-		WriteJson(w, http.StatusOK, map[string]interface{}{})
+	}
+
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	_, err = client.SnoozeTask(r.Context(), &pb.SnoozeTaskRequest{
+		Id:   int32(id),
+		Days: int32(days),
+		Date: r.URL.Query().Get("date"),
+	})
+	if err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, err)
 		return
 	}
 
-	// Периодическая задача — вычисляем следующую дату
-	nextDate, err := cm.NextDate(time.Now(), task.Task.Date, task.Task.Repeat)
+	WriteJson(w, http.StatusOK, map[string]interface{}{})
+}
+
+// skipOccurrenceHandler обработчик POST /api/task/skip?id=[&date=]
+func (app *AppAPI) skipOccurrenceHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	id, err := GetIDFromQuery(w, r)
 	if err != nil {
-		WriteJson(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		writeError(w, r, err)
 		return
 	}
 
-	_, err = client.UpdateDate(app.context, &pb.UpdateDateRequest{
-		Id:       int32(id),
-		NextDate: nextDate,
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	_, err = client.SkipOccurrence(r.Context(), &pb.SkipOccurrenceRequest{
+		Id:   int32(id),
+		Date: r.URL.Query().Get("date"),
 	})
 	if err != nil {
 		log.Println("error: ", err)
-		WriteJson(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		writeError(w, r, err)
 		return
 	}
 
@@ -193,34 +273,37 @@ func (app *AppAPI) doneTaskHandler(w http.ResponseWriter, r *http.Request) {
 
 func (app *AppAPI) nextDateHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		WriteJson(w, http.StatusMethodNotAllowed, map[string]string{"error": "Method not allowed"})
+		writeMethodNotAllowed(w, r)
 		return
 	}
 
 	nowStr := r.URL.Query().Get("now")
 	dateStr := r.URL.Query().Get("date")
 	repeat := r.URL.Query().Get("repeat")
+	tz := r.URL.Query().Get("tz")
 
-	if dateStr == "" || repeat == "" {
-		WriteJson(w, http.StatusBadRequest, map[string]string{"error": "date and repeat parameters are required"})
+	if dateStr == "" {
+		writeError(w, r, apperrors.ErrInvalidParameter.With("name", "date"))
 		return
 	}
-
-	now := time.Now().Format(cm.FormDate)
-	if nowStr != "" {
-		now = nowStr
+	if repeat == "" {
+		writeError(w, r, apperrors.ErrInvalidParameter.With("name", "repeat"))
+		return
 	}
 
+	// без now текущую дату в поясе tz считает db-сервис по своим часам
 	client := pb.NewSchedulerServiceClient(app.conn)
 
-	resp, err := client.NextDate(app.context, &pb.NextDateRequest{
-		CurrentDate: now,
+	resp, err := client.NextDate(r.Context(), &pb.NextDateRequest{
+		CurrentDate: nowStr,
 		TaskDate:    dateStr,
 		RepeatRule:  repeat,
+		Tz:          tz,
 	})
 	if err != nil {
-		log.Println(err)
-		WriteJson(w, http.StatusInternalServerError, map[string]string{"error": "failed to calculate next date"})
+		log.Println("error: ", err)
+		writeError(w, r, err)
+		return
 	}
 
 	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
@@ -229,34 +312,65 @@ func (app *AppAPI) nextDateHandler(w http.ResponseWriter, r *http.Request) {
 
 func (app *AppAPI) tasksHandler(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		WriteJson(w, http.StatusMethodNotAllowed, map[string]string{"error": "Method not allowed"})
+		writeMethodNotAllowed(w, r)
 		return
 	}
 
 	search := r.URL.Query().Get("search")
 	limit := 50
 
+	// ?priority=high — задачи не ниже high, ?order=priority — сначала важные,
+	// ?tag=home&tag=infra — задачи со всеми метками, ?status=todo&status=waiting — в любом из статусов
+	priority, err := md.ParsePriority(r.URL.Query().Get("priority"))
+	if err != nil {
+		writeError(w, r, fmt.Errorf("%w: %w", apperrors.ErrInvalidParameter.With("name", "priority"), err))
+		return
+	}
+	order, err := md.CheckOrder(r.URL.Query().Get("order"))
+	if err != nil {
+		writeError(w, r, fmt.Errorf("%w: %w", apperrors.ErrInvalidParameter.With("name", "order"), err))
+		return
+	}
+	var statuses []pb.Status
+	for _, name := range r.URL.Query()["status"] {
+		status, err := parseStatusParam(name)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		statuses = append(statuses, pb.Status(status))
+	}
+
+	// ?project_id=N — задачи проекта, поиск и фильтры действуют внутри него
+	var projectID int
+	if v := r.URL.Query().Get("project_id"); v != "" {
+		projectID, err = strconv.Atoi(v)
+		if err != nil || projectID < 0 {
+			writeError(w, r, apperrors.ErrInvalidParameter.With("name", "project_id"))
+			return
+		}
+	}
+
 	client := pb.NewSchedulerServiceClient(app.conn)
 
-	resp, err := client.ListTasks(app.context, &pb.ListTasksRequest{
-		Limit:  int32(limit),
-		Search: search,
+	resp, err := client.ListTasks(r.Context(), &pb.ListTasksRequest{
+		Limit:       int32(limit),
+		Search:      search,
+		MinPriority: pb.Priority(priority),
+		Statuses:    statuses,
+		Order:       order,
+		Tags:        r.URL.Query()["tag"],
+		ProjectId:   int32(projectID),
 	})
 	if err != nil {
 		log.Println("error: ", err)
-		WriteJson(w, http.StatusInternalServerError, map[string]string{"error": "failed to fetch tasks"})
+		writeError(w, r, err)
 		return
 	}
 
 	var tasks []*md.Task
 	for _, protoTask := range resp.Tasks {
-		tasks = append(tasks, &md.Task{
-			ID:      int(protoTask.Id),
-			Date:    protoTask.Date,
-			Title:   protoTask.Title,
-			Comment: protoTask.Comment,
-			Repeat:  protoTask.Repeat,
-		})
+		tasks = append(tasks, taskFromProto(protoTask))
 	}
 
 	if tasks == nil {
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+)
+
+// requestLang выбирает язык сообщений по Accept-Language (с учетом q),
+// без подходящего языка — язык по умолчанию
+func requestLang(r *http.Request) string {
+	best, bestQ := apperrors.DefaultLang, 0.0
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		// ru-RU → ru
+		lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if q > bestQ && apperrors.Supported(lang) {
+			best, bestQ = lang, q
+		}
+	}
+	return best
+}
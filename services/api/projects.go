@@ -0,0 +1,173 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/common/validate"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// projectRequest тело POST и PUT /api/project
+type projectRequest struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// projectIDFromQuery читает обязательный параметр id проекта
+func projectIDFromQuery(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", apperrors.ErrInvalidParameter.With("name", "id"), err)
+	}
+	return id, nil
+}
+
+// projectsHandler обработчик GET /api/projects[?archived=true] — проекты с числом задач
+func (app *AppAPI) projectsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	includeArchived := false
+	if v := r.URL.Query().Get("archived"); v != "" {
+		var err error
+		if includeArchived, err = strconv.ParseBool(v); err != nil {
+			writeError(w, r, fmt.Errorf("%w: %w", apperrors.ErrInvalidParameter.With("name", "archived"), err))
+			return
+		}
+	}
+
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	resp, err := client.ListProjects(r.Context(), &pb.ListProjectsRequest{IncludeArchived: includeArchived})
+	if err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, err)
+		return
+	}
+
+	projects := []*md.Project{}
+	for _, p := range resp.Projects {
+		projects = append(projects, projectFromProto(p))
+	}
+
+	WriteJson(w, http.StatusOK, map[string]interface{}{"projects": projects})
+}
+
+// projectHandler обработчик /api/project: GET ?id= — проект, POST {"name","description"} — создать,
+// PUT {"id","name","description"} — изменить, DELETE ?id= — удалить, задачи остаются без проекта
+func (app *AppAPI) projectHandler(w http.ResponseWriter, r *http.Request) {
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	var (
+		project *pb.Project
+		err     error
+	)
+	switch r.Method {
+	case http.MethodGet, http.MethodDelete:
+		id, idErr := projectIDFromQuery(r)
+		if idErr != nil {
+			writeError(w, r, idErr)
+			return
+		}
+		if r.Method == http.MethodGet {
+			project, err = client.GetProject(r.Context(), &pb.IDRequest{Id: int32(id)})
+		} else {
+			_, err = client.DeleteProject(r.Context(), &pb.IDRequest{Id: int32(id)})
+		}
+	case http.MethodPost, http.MethodPut:
+		var req projectRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, r, fmt.Errorf("%w: %w", apperrors.ErrInvalidJSON, err))
+			return
+		}
+		pbReq := &pb.Project{Id: int32(req.ID), Name: req.Name, Description: req.Description}
+		if err := validate.Message(pbReq); err != nil {
+			writeError(w, r, err)
+			return
+		}
+		if r.Method == http.MethodPost {
+			project, err = client.CreateProject(r.Context(), pbReq)
+		} else {
+			project, err = client.UpdateProject(r.Context(), pbReq)
+		}
+	default:
+		writeMethodNotAllowed(w, r)
+		return
+	}
+	if err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, err)
+		return
+	}
+
+	if project == nil {
+		WriteJson(w, http.StatusOK, map[string]interface{}{})
+		return
+	}
+	WriteJson(w, http.StatusOK, projectFromProto(project))
+}
+
+// projectArchiveHandler обработчик /api/project/archive?id=: PUT — в архив, DELETE — из архива
+func (app *AppAPI) projectArchiveHandler(w http.ResponseWriter, r *http.Request) {
+	var archived bool
+	switch r.Method {
+	case http.MethodPut:
+		archived = true
+	case http.MethodDelete:
+		archived = false
+	default:
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	id, err := projectIDFromQuery(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	project, err := client.ArchiveProject(r.Context(), &pb.ArchiveProjectRequest{Id: int32(id), Archived: archived})
+	if err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, err)
+		return
+	}
+
+	WriteJson(w, http.StatusOK, projectFromProto(project))
+}
+
+// projectStatsHandler обработчик GET /api/project/stats?id= — сводка по задачам проекта
+func (app *AppAPI) projectStatsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	id, err := projectIDFromQuery(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	stats, err := client.GetProjectStats(r.Context(), &pb.IDRequest{Id: int32(id)})
+	if err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, err)
+		return
+	}
+
+	WriteJson(w, http.StatusOK, projectStatsFromProto(stats))
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// заголовки HTTP и ключи метаданных gRPC со сведениями о запросе для журнала аудита db-сервиса.
+// Заголовки задает клиент, поэтому им верят, только если запрос пришел от прокси из
+// TRUSTED_PROXIES: прокси аутентифицирует пользователя и сам выставляет X-Actor, затирая
+// заголовок клиента. От остальных адресов заголовки отбрасываются: автор в журнале пуст,
+// id запроса создает API. db-сервис верит метаданным, поэтому его порт доступен только API.
+const (
+	requestIDHeader = "X-Request-ID"
+	actorHeader     = "X-Actor"
+
+	requestIDKey  = "x-request-id"
+	actorKey      = "x-actor"
+	clientAddrKey = "x-client-addr"
+)
+
+// parseTrustedProxies разбирает адреса доверенных прокси: IP или подсеть CIDR
+func parseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if strings.Contains(v, "/") {
+			prefix, err := netip.ParsePrefix(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid TRUSTED_PROXIES entry %q: %w", v, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TRUSTED_PROXIES entry %q: %w", v, err)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// trustedPeer пришел ли запрос с адреса доверенного прокси
+func trustedPeer(r *http.Request, trusted []netip.Prefix) bool {
+	addrPort, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	addr := addrPort.Addr().Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// requestMeta передает db-сервису id запроса, автора и адрес клиента в метаданных
+// всех вызовов запроса. Id без заголовка (или от недоверенного клиента) создается,
+// в ответе возвращается всегда.
+func requestMeta(trusted []netip.Prefix, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !trustedPeer(r, trusted) {
+			r.Header.Del(requestIDHeader)
+			r.Header.Del(actorHeader)
+		}
+
+		id := r.Header.Get(requestIDHeader)
+		if id == "" {
+			id = newRequestID()
+		}
+		w.Header().Set(requestIDHeader, id)
+
+		ctx := metadata.AppendToOutgoingContext(r.Context(),
+			requestIDKey, printable(id),
+			actorKey, printable(r.Header.Get(actorHeader)),
+			clientAddrKey, r.RemoteAddr,
+		)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// newRequestID случайный id запроса, 16 байт в hex
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// printable заменяет символы, которые нельзя передать в метаданных gRPC, на '?':
+// иначе вызов db-сервиса завершился бы ошибкой
+func printable(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e {
+			return '?'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	prefixes, err := parseTrustedProxies([]string{"10.0.0.0/8", " 192.168.1.5 ", "", "::1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.5/32"),
+		netip.MustParsePrefix("::1/128"),
+	}
+	if len(prefixes) != len(want) {
+		t.Fatalf("prefixes = %v, want %v", prefixes, want)
+	}
+	for i := range want {
+		if prefixes[i] != want[i] {
+			t.Errorf("prefixes[%d] = %v, want %v", i, prefixes[i], want[i])
+		}
+	}
+
+	if _, err := parseTrustedProxies([]string{"proxy"}); err == nil {
+		t.Error("parseTrustedProxies(proxy) error = nil")
+	}
+}
+
+func TestRequestMeta(t *testing.T) {
+	trusted, err := parseTrustedProxies([]string{"10.0.0.0/8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name       string
+		remoteAddr string
+		wantActor  string
+		keepID     bool // id запроса берется из заголовка клиента
+	}{
+		{name: "trusted proxy", remoteAddr: "10.1.2.3:5000", wantActor: "alice", keepID: true},
+		{name: "untrusted client", remoteAddr: "203.0.113.7:5000"},
+		{name: "mapped untrusted", remoteAddr: "[::ffff:203.0.113.7]:5000"},
+		{name: "mapped trusted", remoteAddr: "[::ffff:10.1.2.3]:5000", wantActor: "alice", keepID: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var md metadata.MD
+			var seenActor string
+			handler := requestMeta(trusted, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				md, _ = metadata.FromOutgoingContext(r.Context())
+				seenActor = r.Header.Get(actorHeader)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/tasks", nil)
+			req.RemoteAddr = tc.remoteAddr
+			req.Header.Set(actorHeader, "alice")
+			req.Header.Set(requestIDHeader, "client-id")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if got := md.Get(actorKey); len(got) != 1 || got[0] != tc.wantActor {
+				t.Errorf("actor metadata = %q, want %q", got, tc.wantActor)
+			}
+			if seenActor != tc.wantActor {
+				t.Errorf("handler actor header = %q, want %q", seenActor, tc.wantActor)
+			}
+
+			id := rec.Header().Get(requestIDHeader)
+			if got := md.Get(requestIDKey); len(got) != 1 || got[0] != id {
+				t.Errorf("request id metadata = %q, response header = %q", got, id)
+			}
+			if (id == "client-id") != tc.keepID {
+				t.Errorf("request id = %q, keep client id = %v", id, tc.keepID)
+			}
+			if got := md.Get(clientAddrKey); len(got) != 1 || got[0] != tc.remoteAddr {
+				t.Errorf("client addr metadata = %q, want %q", got, tc.remoteAddr)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// revisionsHandler обработчик GET /api/task/revisions?id= — прежние версии задачи,
+// у каждой список полей, измененных следующей версией
+func (app *AppAPI) revisionsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	id, err := GetIDFromQuery(w, r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	resp, err := client.ListRevisions(r.Context(), &pb.IDRequest{Id: int32(id)})
+	if err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, err)
+		return
+	}
+
+	revisions := []*md.TaskRevision{}
+	for _, revision := range resp.Revisions {
+		revisions = append(revisions, revisionFromProto(revision))
+	}
+	WriteJson(w, http.StatusOK, map[string]interface{}{"revisions": revisions})
+}
+
+// revisionHandler обработчик /api/task/revision?id=&number=N: GET — версия задачи
+// с измененными полями, POST — вернуть задаче поля версии, возвращает задачу
+func (app *AppAPI) revisionHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := GetIDFromQuery(w, r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	number, err := strconv.Atoi(r.URL.Query().Get("number"))
+	if err != nil || number <= 0 {
+		writeError(w, r, apperrors.ErrInvalidParameter.With("name", "number"))
+		return
+	}
+
+	client := pb.NewSchedulerServiceClient(app.conn)
+	req := &pb.RevisionRequest{TaskId: int32(id), Number: int32(number)}
+
+	switch r.Method {
+	case http.MethodGet:
+		revision, err := client.GetRevision(r.Context(), req)
+		if err != nil {
+			log.Println("error: ", err)
+			writeError(w, r, err)
+			return
+		}
+		WriteJson(w, http.StatusOK, revisionFromProto(revision))
+	case http.MethodPost:
+		task, err := client.RevertTask(r.Context(), req)
+		if err != nil {
+			log.Println("error: ", err)
+			writeError(w, r, err)
+			return
+		}
+		WriteJson(w, http.StatusOK, taskFromProto(task))
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
@@ -16,9 +16,7 @@ func (app *AppAPI) taskHandler(w http.ResponseWriter, r *http.Request) {
 	case http.MethodDelete:
 		app.DeleteTaskHandler(w, r)
 	default:
-		WriteJson(w, http.StatusMethodNotAllowed, map[string]string{
-			"error": "Method not allowed",
-		})
+		writeMethodNotAllowed(w, r)
 	}
 }
 func (app *AppAPI) init() {
@@ -26,6 +24,25 @@ func (app *AppAPI) init() {
 	http.HandleFunc("/api/task", func(w http.ResponseWriter, r *http.Request) { app.taskHandler(w, r) })
 	http.HandleFunc("/api/tasks", func(w http.ResponseWriter, r *http.Request) { app.tasksHandler(w, r) })
 	http.HandleFunc("/api/task/done", func(w http.ResponseWriter, r *http.Request) { app.doneTaskHandler(w, r) })
+	http.HandleFunc("/api/task/snooze", func(w http.ResponseWriter, r *http.Request) { app.snoozeTaskHandler(w, r) })
+	http.HandleFunc("/api/task/skip", func(w http.ResponseWriter, r *http.Request) { app.skipOccurrenceHandler(w, r) })
+	http.HandleFunc("/api/task/subtasks", func(w http.ResponseWriter, r *http.Request) { app.subtasksHandler(w, r) })
+	http.HandleFunc("/api/task/checklist", func(w http.ResponseWriter, r *http.Request) { app.checklistHandler(w, r) })
+	http.HandleFunc("/api/task/dependencies", func(w http.ResponseWriter, r *http.Request) { app.dependenciesHandler(w, r) })
+	http.HandleFunc("/api/task/status", func(w http.ResponseWriter, r *http.Request) { app.statusHandler(w, r) })
+	http.HandleFunc("/api/task/revisions", func(w http.ResponseWriter, r *http.Request) { app.revisionsHandler(w, r) })
+	http.HandleFunc("/api/task/revision", func(w http.ResponseWriter, r *http.Request) { app.revisionHandler(w, r) })
+	http.HandleFunc("/api/task/attachments", func(w http.ResponseWriter, r *http.Request) { app.attachmentsHandler(w, r) })
+	http.HandleFunc("/api/task/attachment", func(w http.ResponseWriter, r *http.Request) { app.attachmentHandler(w, r) })
+	http.HandleFunc("/api/task/missed", func(w http.ResponseWriter, r *http.Request) { app.missedHandler(w, r) })
+	http.HandleFunc("/api/tags", func(w http.ResponseWriter, r *http.Request) { app.tagsHandler(w, r) })
+	http.HandleFunc("/api/tag", func(w http.ResponseWriter, r *http.Request) { app.tagHandler(w, r) })
+	http.HandleFunc("/api/tag/merge", func(w http.ResponseWriter, r *http.Request) { app.tagMergeHandler(w, r) })
+	http.HandleFunc("/api/projects", func(w http.ResponseWriter, r *http.Request) { app.projectsHandler(w, r) })
+	http.HandleFunc("/api/project", func(w http.ResponseWriter, r *http.Request) { app.projectHandler(w, r) })
+	http.HandleFunc("/api/project/archive", func(w http.ResponseWriter, r *http.Request) { app.projectArchiveHandler(w, r) })
+	http.HandleFunc("/api/project/stats", func(w http.ResponseWriter, r *http.Request) { app.projectStatsHandler(w, r) })
+	http.HandleFunc("/api/admin/clock", func(w http.ResponseWriter, r *http.Request) { app.clockHandler(w, r) })
 
 	http.Handle("/", http.FileServer(http.Dir("./web")))
 
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	cfg "github.com/Vasya-lis/firstWorkWithgRPC/config"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+)
+
+// методы db-сервиса, которые только читают данные и безопасно повторяются
+var idempotentMethods = map[string]bool{
+	"GetTask":   true,
+	"ListTasks": true,
+	"NextDate":  true,
+}
+
+// предел попыток в gRPC, большее значение клиент все равно урежет
+const maxRPCAttempts = 5
+
+// структура service config gRPC (см. grpc/service_config.proto)
+type serviceConfig struct {
+	MethodConfig []methodConfig `json:"methodConfig"`
+}
+
+type methodName struct {
+	Service string `json:"service"`
+	Method  string `json:"method,omitempty"`
+}
+
+type methodConfig struct {
+	Name        []methodName `json:"name"`
+	Timeout     string       `json:"timeout,omitempty"`
+	RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+}
+
+type retryPolicy struct {
+	MaxAttempts          int      `json:"maxAttempts"`
+	InitialBackoff       string   `json:"initialBackoff"`
+	MaxBackoff           string   `json:"maxBackoff"`
+	BackoffMultiplier    float64  `json:"backoffMultiplier"`
+	RetryableStatusCodes []string `json:"retryableStatusCodes"`
+}
+
+// buildServiceConfig собирает service config клиента: дедлайн для всех методов,
+// длинный дедлайн потоков вложений, отдельные дедлайны из RPC_TIMEOUTS
+// и повторы идемпотентных методов при UNAVAILABLE
+func buildServiceConfig(conf *cfg.Config) (string, error) {
+	if conf.RPCTimeout <= 0 {
+		return "", fmt.Errorf("invalid RPC_TIMEOUT %v", conf.RPCTimeout)
+	}
+	if conf.RPCStreamTimeout <= 0 {
+		return "", fmt.Errorf("invalid RPC_STREAM_TIMEOUT %v", conf.RPCStreamTimeout)
+	}
+	if conf.RPCMaxAttempts < 1 || conf.RPCMaxAttempts > maxRPCAttempts {
+		return "", fmt.Errorf("invalid RPC_MAX_ATTEMPTS %d, expected 1..%d", conf.RPCMaxAttempts, maxRPCAttempts)
+	}
+
+	service := pb.SchedulerService_ServiceDesc.ServiceName
+	var methods []string
+	for _, m := range pb.SchedulerService_ServiceDesc.Methods {
+		methods = append(methods, m.MethodName)
+	}
+	// потоки передают вложения целиком, общего дедлайна на большой файл не хватит
+	streams := make(map[string]bool)
+	for _, m := range pb.SchedulerService_ServiceDesc.Streams {
+		methods = append(methods, m.StreamName)
+		streams[m.StreamName] = true
+	}
+	known := make(map[string]bool)
+	for _, m := range methods {
+		known[m] = true
+	}
+	for method, timeout := range conf.RPCTimeouts {
+		if !known[method] {
+			return "", fmt.Errorf("unknown method %q in RPC_TIMEOUTS", method)
+		}
+		if timeout <= 0 {
+			return "", fmt.Errorf("invalid timeout %v for %s in RPC_TIMEOUTS", timeout, method)
+		}
+	}
+
+	sc := serviceConfig{
+		MethodConfig: []methodConfig{{
+			Name:    []methodName{{Service: service}},
+			Timeout: durationJSON(conf.RPCTimeout),
+		}},
+	}
+	// настройки метода перекрывают общие, поэтому у метода повторяем дедлайн и политику целиком
+	for _, m := range methods {
+		timeout, custom := conf.RPCTimeouts[m]
+		// потоков нет среди идемпотентных: повтор начал бы передачу файла заново
+		retry := idempotentMethods[m] && conf.RPCMaxAttempts > 1
+		if !custom && !retry && !streams[m] {
+			continue
+		}
+		if !custom {
+			timeout = conf.RPCTimeout
+			if streams[m] {
+				timeout = conf.RPCStreamTimeout
+			}
+		}
+		mc := methodConfig{
+			Name:    []methodName{{Service: service, Method: m}},
+			Timeout: durationJSON(timeout),
+		}
+		if retry {
+			mc.RetryPolicy = &retryPolicy{
+				MaxAttempts:          conf.RPCMaxAttempts,
+				InitialBackoff:       "0.1s",
+				MaxBackoff:           "1s",
+				BackoffMultiplier:    2,
+				RetryableStatusCodes: []string{"UNAVAILABLE"},
+			}
+		}
+		sc.MethodConfig = append(sc.MethodConfig, mc)
+	}
+
+	data, err := json.Marshal(sc)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// durationJSON формат длительности в service config: секунды с суффиксом s
+func durationJSON(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
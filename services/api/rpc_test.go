@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	cfg "github.com/Vasya-lis/firstWorkWithgRPC/config"
+)
+
+func TestBuildServiceConfig(t *testing.T) {
+	conf := &cfg.Config{
+		RPCTimeout:       5 * time.Second,
+		RPCStreamTimeout: 10 * time.Minute,
+		RPCTimeouts:      map[string]time.Duration{"ListTasks": 3 * time.Second},
+		RPCMaxAttempts:   3,
+	}
+	data, err := buildServiceConfig(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sc serviceConfig
+	if err := json.Unmarshal([]byte(data), &sc); err != nil {
+		t.Fatal(err)
+	}
+
+	methods := make(map[string]methodConfig)
+	for _, mc := range sc.MethodConfig {
+		methods[mc.Name[0].Method] = mc
+	}
+	cases := []struct {
+		method  string // пустое — общие настройки сервиса
+		timeout string
+		retry   bool
+	}{
+		{method: "", timeout: "5s"},
+		{method: "GetTask", timeout: "5s", retry: true},
+		{method: "ListTasks", timeout: "3s", retry: true},
+		{method: "UploadAttachment", timeout: "600s"},
+		{method: "DownloadAttachment", timeout: "600s"},
+	}
+	for _, tc := range cases {
+		mc, ok := methods[tc.method]
+		if !ok {
+			t.Errorf("%q: no method config", tc.method)
+			continue
+		}
+		if mc.Timeout != tc.timeout || (mc.RetryPolicy != nil) != tc.retry {
+			t.Errorf("%q: timeout %s, retry %v, want %s, %v", tc.method, mc.Timeout, mc.RetryPolicy != nil, tc.timeout, tc.retry)
+		}
+	}
+	if _, ok := methods["AddTask"]; ok {
+		t.Error("AddTask has its own method config, want the service default")
+	}
+
+	// RPC_TIMEOUTS перекрывает дедлайн потока
+	conf.RPCTimeouts = map[string]time.Duration{"UploadAttachment": time.Hour}
+	data, err = buildServiceConfig(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc = serviceConfig{}
+	if err := json.Unmarshal([]byte(data), &sc); err != nil {
+		t.Fatal(err)
+	}
+	for _, mc := range sc.MethodConfig {
+		if mc.Name[0].Method == "UploadAttachment" && mc.Timeout != "3600s" {
+			t.Errorf("UploadAttachment timeout = %s, want 3600s", mc.Timeout)
+		}
+	}
+
+	conf.RPCStreamTimeout = 0
+	if _, err := buildServiceConfig(conf); err == nil {
+		t.Error("buildServiceConfig() with zero RPC_STREAM_TIMEOUT error = nil")
+	}
+}
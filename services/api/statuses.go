@@ -0,0 +1,78 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// statusHandler обработчик /api/task/status?id=: GET — история статусов,
+// PUT &status=in_progress — сменить статус, возвращает задачу
+func (app *AppAPI) statusHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := GetIDFromQuery(w, r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	switch r.Method {
+	case http.MethodGet:
+		resp, err := client.ListStatusChanges(r.Context(), &pb.IDRequest{Id: int32(id)})
+		if err != nil {
+			log.Println("error: ", err)
+			writeError(w, r, err)
+			return
+		}
+
+		changes := []*md.StatusChange{}
+		for _, c := range resp.Changes {
+			changedAt, _ := time.Parse(time.RFC3339, c.ChangedAt)
+			changes = append(changes, &md.StatusChange{
+				ID:        int(c.Id),
+				TaskID:    int(c.TaskId),
+				From:      md.Status(c.From),
+				To:        md.Status(c.To),
+				ChangedAt: changedAt,
+			})
+		}
+		WriteJson(w, http.StatusOK, map[string]interface{}{"changes": changes})
+	case http.MethodPut:
+		status, err := parseStatusParam(r.URL.Query().Get("status"))
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		task, err := client.SetStatus(r.Context(), &pb.SetStatusRequest{
+			Id:     int32(id),
+			Status: pb.Status(status),
+		})
+		if err != nil {
+			log.Println("error: ", err)
+			writeError(w, r, err)
+			return
+		}
+		WriteJson(w, http.StatusOK, taskFromProto(task))
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// parseStatusParam разбирает статус из параметра запроса, пустой — ошибка
+func parseStatusParam(name string) (md.Status, error) {
+	if name == "" {
+		return md.StatusUnspecified, apperrors.ErrInvalidParameter.With("name", "status")
+	}
+	status, err := md.ParseStatus(name)
+	if err != nil {
+		return md.StatusUnspecified, fmt.Errorf("%w: %w", apperrors.ErrInvalidParameter.With("name", "status"), err)
+	}
+	return status, nil
+}
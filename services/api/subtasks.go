@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/common/validate"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// subtasksHandler обработчик /api/task/subtasks?id=: GET — прямые подзадачи,
+// POST с задачей в теле — создать подзадачу
+func (app *AppAPI) subtasksHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := GetIDFromQuery(w, r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	switch r.Method {
+	case http.MethodGet:
+		resp, err := client.ListSubtasks(r.Context(), &pb.IDRequest{Id: int32(id)})
+		if err != nil {
+			log.Println("error: ", err)
+			writeError(w, r, err)
+			return
+		}
+
+		tasks := []*md.Task{}
+		for _, protoTask := range resp.Tasks {
+			tasks = append(tasks, taskFromProto(protoTask))
+		}
+		WriteJson(w, http.StatusOK, TasksResponse{Tasks: tasks})
+	case http.MethodPost:
+		var task md.Task
+		if err := json.NewDecoder(r.Body).Decode(&task); err != nil {
+			writeError(w, r, fmt.Errorf("%w: %w", apperrors.ErrInvalidJSON, err))
+			return
+		}
+		task.ParentID = &id
+
+		pbTask := taskToProto(&task)
+		if err := validate.Message(pbTask); err != nil {
+			writeError(w, r, err)
+			return
+		}
+
+		resp, err := client.AddTask(r.Context(), pbTask)
+		if err != nil {
+			log.Println("error: ", err)
+			writeError(w, r, err)
+			return
+		}
+		WriteJson(w, http.StatusOK, map[string]int{"id": int(resp.Id)})
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// checklistHandler обработчик PUT /api/task/checklist?id=&item=N&done=true|false —
+// отметить пункт чек-листа (с нуля) или снять отметку, возвращает задачу
+func (app *AppAPI) checklistHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	id, err := GetIDFromQuery(w, r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	item, err := strconv.Atoi(r.URL.Query().Get("item"))
+	if err != nil {
+		writeError(w, r, fmt.Errorf("%w: %w", apperrors.ErrInvalidParameter.With("name", "item"), err))
+		return
+	}
+	done := true
+	if v := r.URL.Query().Get("done"); v != "" {
+		if done, err = strconv.ParseBool(v); err != nil {
+			writeError(w, r, fmt.Errorf("%w: %w", apperrors.ErrInvalidParameter.With("name", "done"), err))
+			return
+		}
+	}
+
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	task, err := client.CheckItem(r.Context(), &pb.CheckItemRequest{
+		TaskId: int32(id),
+		Index:  int32(item),
+		Done:   done,
+	})
+	if err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, err)
+		return
+	}
+
+	WriteJson(w, http.StatusOK, taskFromProto(task))
+}
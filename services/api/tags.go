@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/common/validate"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// tagRequest тело POST и PUT /api/tag
+type tagRequest struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// mergeTagsRequest тело POST /api/tag/merge
+type mergeTagsRequest struct {
+	SourceIDs []int `json:"source_ids"`
+	TargetID  int   `json:"target_id"`
+}
+
+// tagsHandler обработчик GET /api/tags — метки с числом задач
+func (app *AppAPI) tagsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	resp, err := client.ListTags(r.Context(), &pb.EmptyRequest{})
+	if err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, err)
+		return
+	}
+
+	tags := []*md.Tag{}
+	for _, t := range resp.Tags {
+		tags = append(tags, tagFromProto(t))
+	}
+
+	WriteJson(w, http.StatusOK, map[string]interface{}{"tags": tags})
+}
+
+// tagHandler обработчик /api/tag: POST {"name"} — создать, PUT {"id","name"} — переименовать,
+// DELETE ?id= — удалить метку и снять ее с задач
+func (app *AppAPI) tagHandler(w http.ResponseWriter, r *http.Request) {
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	var (
+		tag *pb.Tag
+		err error
+	)
+	switch r.Method {
+	case http.MethodPost:
+		var req tagRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, r, fmt.Errorf("%w: %w", apperrors.ErrInvalidJSON, err))
+			return
+		}
+		pbReq := &pb.CreateTagRequest{Name: req.Name}
+		if err := validate.Message(pbReq); err != nil {
+			writeError(w, r, err)
+			return
+		}
+		tag, err = client.CreateTag(r.Context(), pbReq)
+	case http.MethodPut:
+		var req tagRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, r, fmt.Errorf("%w: %w", apperrors.ErrInvalidJSON, err))
+			return
+		}
+		pbReq := &pb.RenameTagRequest{Id: int32(req.ID), Name: req.Name}
+		if err := validate.Message(pbReq); err != nil {
+			writeError(w, r, err)
+			return
+		}
+		tag, err = client.RenameTag(r.Context(), pbReq)
+	case http.MethodDelete:
+		id, convErr := strconv.Atoi(r.URL.Query().Get("id"))
+		if convErr != nil {
+			writeError(w, r, fmt.Errorf("%w: %w", apperrors.ErrInvalidParameter.With("name", "id"), convErr))
+			return
+		}
+		_, err = client.DeleteTag(r.Context(), &pb.IDRequest{Id: int32(id)})
+	default:
+		writeMethodNotAllowed(w, r)
+		return
+	}
+	if err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, err)
+		return
+	}
+
+	if tag == nil {
+		WriteJson(w, http.StatusOK, map[string]interface{}{})
+		return
+	}
+	WriteJson(w, http.StatusOK, tagFromProto(tag))
+}
+
+// tagMergeHandler обработчик POST /api/tag/merge — перенести задачи меток source_ids
+// на метку target_id и удалить исходные
+func (app *AppAPI) tagMergeHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	var req mergeTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, fmt.Errorf("%w: %w", apperrors.ErrInvalidJSON, err))
+		return
+	}
+	pbReq := &pb.MergeTagsRequest{SourceIds: idsToProto(req.SourceIDs), TargetId: int32(req.TargetID)}
+	if err := validate.Message(pbReq); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	client := pb.NewSchedulerServiceClient(app.conn)
+
+	tag, err := client.MergeTags(r.Context(), pbReq)
+	if err != nil {
+		log.Println("error: ", err)
+		writeError(w, r, err)
+		return
+	}
+
+	WriteJson(w, http.StatusOK, tagFromProto(tag))
+}
@@ -5,3 +5,10 @@ import md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
 type TasksResponse struct {
 	Tasks []*md.Task `json:"tasks"`
 }
+
+// DoneTaskResponse ответ на выполнение задачи: перенесенная задача или,
+// если finished, последнее состояние удаленной
+type DoneTaskResponse struct {
+	Task     *md.Task `json:"task"`
+	Finished bool     `json:"finished"`
+}
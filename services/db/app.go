@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"time"
 
 	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
 	"github.com/Vasya-lis/firstWorkWithgRPC/services/db/cache"
 	"github.com/Vasya-lis/firstWorkWithgRPC/services/db/repo"
 
+	cm "github.com/Vasya-lis/firstWorkWithgRPC/common"
+	"github.com/Vasya-lis/firstWorkWithgRPC/common/blob"
 	cmDB "github.com/Vasya-lis/firstWorkWithgRPC/common/db"
 	cmR "github.com/Vasya-lis/firstWorkWithgRPC/common/redis"
 	cfg "github.com/Vasya-lis/firstWorkWithgRPC/config"
@@ -21,6 +24,7 @@ type AppDB struct {
 	tasks  *TasksService // сервис тасков
 	server *grpc.Server  // grpc сервер
 	ctx    context.Context
+	cancel context.CancelFunc // останавливает фоновые задачи
 }
 
 func NewAppDB() (*AppDB, error) {
@@ -30,6 +34,14 @@ func NewAppDB() (*AppDB, error) {
 		log.Printf("configuration error: %v", err)
 		return nil, fmt.Errorf("configuration failed: %w", err)
 	}
+	if err := cm.SetDefaultLocation(config.DefaultTZ); err != nil {
+		log.Printf("configuration error: %v", err)
+		return nil, fmt.Errorf("configuration failed: %w", err)
+	}
+	if err := cm.InitCalendar(config.CalendarPath); err != nil {
+		log.Printf("calendar init failed: %v", err)
+		return nil, fmt.Errorf("calendar init failed: %w", err)
+	}
 
 	// инициализация базы
 	if err := cmDB.InitDB(); err != nil {
@@ -42,21 +54,40 @@ func NewAppDB() (*AppDB, error) {
 	cmR.InitRedis(config.RedisAddr)
 	client := cmR.GetRedis()
 
+	// часы сервиса, на стенде могут быть заморожены
+	clock, err := cm.NewClock(config.FrozenTime)
+	if err != nil {
+		log.Printf("configuration error: %v", err)
+		return nil, fmt.Errorf("configuration failed: %w", err)
+	}
+
+	// хранилище вложений
+	blobs, err := newBlobStore(config)
+	if err != nil {
+		log.Printf("blob store init failed: %v", err)
+		return nil, fmt.Errorf("blob store init failed: %w", err)
+	}
+
 	// создание слоев приложения
-	taskRepo := repo.NewTasksRepo(db)                    // работа с бд
-	taskCache := cache.NewTasksCache(client)             // кэш
-	tasksService := NewTasksService(taskRepo, taskCache) // сервис
+	taskRepo := repo.NewTasksRepo(db, clock)                                                     // работа с бд
+	taskCache := cache.NewTasksCache(client)                                                     // кэш
+	tasksService := NewTasksService(taskRepo, taskCache, clock, blobs, config.AttachmentMaxSize) // сервис
 
 	//создание gRPC сервера
-	grpcServer := grpc.NewServer()
-	tasksServer := NewTasksServer(tasksService)
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(validateUnary),
+		grpc.StreamInterceptor(validateStream),
+	)
+	tasksServer := NewTasksServer(tasksService, clock, config.AdminToken)
 	pb.RegisterSchedulerServiceServer(grpcServer, tasksServer)
 
+	ctx, cancel := context.WithCancel(context.Background())
 	app := &AppDB{
 		conf:   config,
 		tasks:  tasksService,
 		server: grpcServer,
-		ctx:    context.Background(),
+		ctx:    ctx,
+		cancel: cancel,
 	}
 
 	// чистим кэш при старте
@@ -70,13 +101,19 @@ func (app *AppDB) Start() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
+	// фоновая обработка пропущенных повторений
+	if app.conf.CatchUpInterval > 0 {
+		go app.tasks.RunCatchUp(app.ctx, app.conf.CatchUpInterval)
+	}
+
 	log.Printf("db-service running on :%s", app.conf.GRPCPort)
 	if err := app.server.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
 func (app *AppDB) Stop() {
-	// остановка сервера grpc
+	// остановка фоновых задач и сервера grpc
+	app.cancel()
 	app.server.GracefulStop()
 	// остановка редиса
 	client := cmR.GetRedis()
@@ -98,3 +135,27 @@ func (app *AppDB) Stop() {
 	}
 
 }
+
+// newBlobStore хранилище вложений по BLOB_STORE
+func newBlobStore(config *cfg.Config) (blob.Store, error) {
+	if config.AttachmentMaxSize <= 0 {
+		return nil, fmt.Errorf("invalid ATTACHMENT_MAX_SIZE %d", config.AttachmentMaxSize)
+	}
+
+	switch config.BlobStore {
+	case "fs":
+		return blob.NewFSStore(config.BlobDir)
+	case "s3":
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return blob.NewS3Store(ctx, blob.S3Config{
+			Endpoint:  config.S3Endpoint,
+			Region:    config.S3Region,
+			Bucket:    config.S3Bucket,
+			AccessKey: config.S3AccessKey,
+			SecretKey: config.S3SecretKey,
+		})
+	default:
+		return nil, fmt.Errorf("unknown BLOB_STORE %q, expected fs or s3", config.BlobStore)
+	}
+}
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+	"google.golang.org/grpc"
+)
+
+// размер части содержимого в потоке выгрузки, намного меньше предела сообщения gRPC
+const attachmentChunkSize = 64 << 10
+
+// ListAttachments возвращает вложения задачи
+func (s *TaskServer) ListAttachments(ctx context.Context, req *pb.IDRequest) (*pb.ListAttachmentsResponse, error) {
+	attachments, err := s.ts.ListAttachments(ctx, int(req.Id))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListAttachmentsResponse{}
+	for _, a := range attachments {
+		resp.Attachments = append(resp.Attachments, attachmentToProto(a))
+	}
+	return resp, nil
+}
+
+// UploadAttachment принимает описание файла первым сообщением потока, затем содержимое
+func (s *TaskServer) UploadAttachment(stream grpc.ClientStreamingServer[pb.UploadAttachmentRequest, pb.Attachment]) error {
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return toStatus(apperrors.ErrAttachmentRequired)
+	}
+	if err != nil {
+		return err
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return toStatus(apperrors.NewFieldError("info", apperrors.ErrFieldRequired))
+	}
+
+	attachment, err := s.ts.AddAttachment(stream.Context(), &md.Attachment{
+		TaskID:      int(info.TaskId),
+		Name:        info.Name,
+		ContentType: info.ContentType,
+	}, &chunkReader{stream: stream})
+	if err != nil {
+		return toStatus(err)
+	}
+	return stream.SendAndClose(attachmentToProto(attachment))
+}
+
+// DownloadAttachment отдает описание вложения первым сообщением потока, затем содержимое
+func (s *TaskServer) DownloadAttachment(req *pb.IDRequest, stream grpc.ServerStreamingServer[pb.DownloadAttachmentResponse]) error {
+	attachment, content, err := s.ts.OpenAttachment(stream.Context(), int(req.Id))
+	if err != nil {
+		return toStatus(err)
+	}
+	defer content.Close()
+
+	info := &pb.DownloadAttachmentResponse{Data: &pb.DownloadAttachmentResponse_Info{Info: attachmentToProto(attachment)}}
+	if err := stream.Send(info); err != nil {
+		return err
+	}
+
+	buf := make([]byte, attachmentChunkSize)
+	for {
+		n, err := content.Read(buf)
+		if n > 0 {
+			chunk := &pb.DownloadAttachmentResponse{Data: &pb.DownloadAttachmentResponse_Chunk{Chunk: buf[:n]}}
+			if err := stream.Send(chunk); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return toStatus(fmt.Errorf("%w: %w", apperrors.ErrBlobStore, err))
+		}
+	}
+}
+
+// DeleteAttachment удаляет вложение
+func (s *TaskServer) DeleteAttachment(ctx context.Context, req *pb.IDRequest) (*pb.EmptyResponse, error) {
+	if err := s.ts.DeleteAttachment(ctx, int(req.Id)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.EmptyResponse{}, nil
+}
+
+// chunkReader читает содержимое из частей потока загрузки
+type chunkReader struct {
+	stream grpc.ClientStreamingServer[pb.UploadAttachmentRequest, pb.Attachment]
+	buf    []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		// описание файла допустимо только первым сообщением
+		if msg.GetInfo() != nil {
+			return 0, apperrors.NewFieldError("info", apperrors.ErrInvalidParameter.With("name", "info"))
+		}
+		r.buf = msg.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
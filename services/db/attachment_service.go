@@ -0,0 +1,170 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/services/db/repo"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// тип содержимого вложения, если клиент его не указал
+const defaultContentType = "application/octet-stream"
+
+// ListAttachments вложения задачи в порядке добавления
+func (s *TasksService) ListAttachments(ctx context.Context, taskID int) ([]*md.Attachment, error) {
+	if _, err := s.tr.GetTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+	return s.tr.Attachments(ctx, taskID)
+}
+
+// AddAttachment сохраняет содержимое content в хранилище, затем метаданные в базе.
+// Содержимое сначала пишется во временный файл: размер проверяется и хэш считается
+// до обращения к хранилищу, а S3 получает длину объекта заранее.
+func (s *TasksService) AddAttachment(ctx context.Context, attachment *md.Attachment, content io.Reader) (*md.Attachment, error) {
+	attachment.Name = attachmentName(attachment.Name)
+	if attachment.Name == "" {
+		return nil, apperrors.NewFieldError("name", apperrors.ErrFieldRequired)
+	}
+	if attachment.ContentType == "" {
+		attachment.ContentType = defaultContentType
+	}
+	// задачу проверяем до приема содержимого, чтобы не принимать файл впустую
+	if _, err := s.tr.GetTask(ctx, attachment.TaskID); err != nil {
+		return nil, err
+	}
+
+	file, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", apperrors.ErrAddAttachment, err)
+	}
+	defer func() {
+		file.Close()
+		os.Remove(file.Name())
+	}()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), io.LimitReader(content, s.maxAttachmentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if size > s.maxAttachmentSize {
+		return nil, apperrors.ErrAttachmentTooLarge.With("max", strconv.FormatInt(s.maxAttachmentSize, 10))
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("%w: %w", apperrors.ErrAddAttachment, err)
+	}
+	attachment.Size = size
+	attachment.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	if attachment.Key, err = newBlobKey(attachment.TaskID); err != nil {
+		return nil, fmt.Errorf("%w: %w", apperrors.ErrAddAttachment, err)
+	}
+
+	if err := s.blobs.Put(ctx, attachment.Key, file, size); err != nil {
+		return nil, fmt.Errorf("%w: %w", apperrors.ErrBlobStore, err)
+	}
+	// задачу могли удалить, пока принималось содержимое
+	err = s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		task, err := tx.GetTaskForUpdate(ctx, attachment.TaskID)
+		if err != nil {
+			return err
+		}
+		if err := tx.AddAttachment(ctx, attachment); err != nil {
+			return err
+		}
+		// вложения в состояние задачи не входят, поэтому пишутся в подробности события
+		return auditDetail(ctx, tx, md.AuditAddAttachment, task.ID, task, task, attachment)
+	})
+	if err != nil {
+		s.deleteBlobs(ctx, attachment.Key)
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// OpenAttachment метаданные вложения и его содержимое, содержимое закрывает вызывающий
+func (s *TasksService) OpenAttachment(ctx context.Context, id int) (*md.Attachment, io.ReadCloser, error) {
+	attachment, err := s.tr.GetAttachment(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := s.blobs.Get(ctx, attachment.Key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", apperrors.ErrBlobStore, err)
+	}
+	return attachment, content, nil
+}
+
+// DeleteAttachment удаляет вложение, содержимое удаляется после фиксации
+func (s *TasksService) DeleteAttachment(ctx context.Context, id int) error {
+	changes := &cacheUpdate{}
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		attachment, err := tx.GetAttachment(ctx, id)
+		if err != nil {
+			return err
+		}
+		task, err := tx.GetTaskForUpdate(ctx, attachment.TaskID)
+		if err != nil {
+			return err
+		}
+		if err := tx.DeleteAttachment(ctx, id); err != nil {
+			return err
+		}
+		changes.blobs = append(changes.blobs, attachment.Key)
+		return auditDetail(ctx, tx, md.AuditDeleteAttachment, task.ID, task, task, attachment)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.applyCache(ctx, changes)
+	return nil
+}
+
+// deleteBlobs удаляет содержимое вложений. Осиротевшее содержимое работе
+// не мешает, поэтому ошибка только логируется.
+func (s *TasksService) deleteBlobs(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			log.Printf("%v: key=%s: %v", apperrors.ErrBlobStore, key, err)
+		}
+	}
+}
+
+// attachmentName имя файла без каталогов и управляющих символов
+func attachmentName(name string) string {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+	if name == "." || name == ".." {
+		return ""
+	}
+	return name
+}
+
+// newBlobKey случайный ключ содержимого: имя файла в ключ не попадает,
+// повторная загрузка того же файла не заменяет прежнюю
+func newBlobKey(taskID int) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("tasks/%d/%s", taskID, hex.EncodeToString(b)), nil
+}
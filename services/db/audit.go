@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/services/db/repo"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ключи метаданных, с которыми API передает сведения о HTTP запросе
+const (
+	requestIDKey  = "x-request-id"
+	actorKey      = "x-actor"
+	clientAddrKey = "x-client-addr"
+)
+
+// размер выборки журнала: по умолчанию и наибольший за один вызов
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// audit пишет событие в журнал в транзакции изменения, поэтому откаченное изменение
+// в журнал не попадает. before и after — задача до и после, nil — задачи нет.
+func audit(ctx context.Context, tx *repo.TasksRepo, action string, taskID int, before, after *md.Task) error {
+	return auditDetail(ctx, tx, action, taskID, before, after, nil)
+}
+
+// auditDetail как audit, detail — то, что изменилось вне самой задачи (например, вложение)
+func auditDetail(ctx context.Context, tx *repo.TasksRepo, action string, taskID int, before, after *md.Task, detail any) error {
+	event := &md.AuditEvent{Action: action, TaskID: taskID}
+
+	// значения из метаданных обрезаются по колонкам: длинный заголовок не должен ломать изменение
+	if m, ok := metadata.FromIncomingContext(ctx); ok {
+		event.RequestID = metadataValue(m, requestIDKey, 64)
+		event.Actor = metadataValue(m, actorKey, 255)
+		event.ClientAddr = metadataValue(m, clientAddrKey, 255)
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		event.SourceAddr = p.Addr.String()
+	}
+
+	var err error
+	if event.Before, err = md.NewAuditState(before); err != nil {
+		return fmt.Errorf("%w: %w", apperrors.ErrAddAuditEvent, err)
+	}
+	if event.After, err = md.NewAuditState(after); err != nil {
+		return fmt.Errorf("%w: %w", apperrors.ErrAddAuditEvent, err)
+	}
+	if event.Detail, err = md.NewAuditDetail(detail); err != nil {
+		return fmt.Errorf("%w: %w", apperrors.ErrAddAuditEvent, err)
+	}
+	return tx.AddAuditEvent(ctx, event)
+}
+
+// auditTasks пишет событие action для каждой задачи из after, before — те же задачи до изменения
+func auditTasks(ctx context.Context, tx *repo.TasksRepo, action string, before, after []*md.Task) error {
+	prev := make(map[int]*md.Task, len(before))
+	for _, task := range before {
+		prev[task.ID] = task
+	}
+	for _, task := range after {
+		if err := audit(ctx, tx, action, task.ID, prev[task.ID], task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// metadataValue первое значение ключа не длиннее max символов
+func metadataValue(m metadata.MD, key string, max int) string {
+	values := m.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	value := []rune(values[0])
+	if len(value) > max {
+		value = value[:max]
+	}
+	return string(value)
+}
+
+// ListAuditEvents возвращает записи журнала по фильтру, не больше maxAuditLimit за вызов
+func (s *TasksService) ListAuditEvents(ctx context.Context, filter md.AuditFilter) ([]*md.AuditEvent, error) {
+	switch {
+	case filter.Limit <= 0:
+		filter.Limit = defaultAuditLimit
+	case filter.Limit > maxAuditLimit:
+		filter.Limit = maxAuditLimit
+	}
+	return s.tr.AuditEvents(ctx, filter)
+}
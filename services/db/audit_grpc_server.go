@@ -0,0 +1,69 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// ListAuditEvents возвращает записи журнала аудита по фильтру, только с токеном администратора
+func (s *TaskServer) ListAuditEvents(ctx context.Context, req *pb.ListAuditEventsRequest) (*pb.ListAuditEventsResponse, error) {
+	if err := s.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	filter := md.AuditFilter{
+		TaskID:    int(req.TaskId),
+		Action:    req.Action,
+		Actor:     req.Actor,
+		RequestID: req.RequestId,
+		AfterID:   int(req.AfterId),
+		Limit:     int(req.Limit),
+	}
+	var err error
+	if filter.Since, err = parseAuditTime("since", req.Since); err != nil {
+		return nil, toStatus(err)
+	}
+	if filter.Until, err = parseAuditTime("until", req.Until); err != nil {
+		return nil, toStatus(err)
+	}
+
+	events, err := s.ts.ListAuditEvents(ctx, filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListAuditEventsResponse{}
+	for _, e := range events {
+		resp.Events = append(resp.Events, &pb.AuditEvent{
+			Id:         int32(e.ID),
+			Action:     e.Action,
+			TaskId:     int32(e.TaskID),
+			Actor:      e.Actor,
+			ClientAddr: e.ClientAddr,
+			SourceAddr: e.SourceAddr,
+			RequestId:  e.RequestID,
+			Before:     string(e.Before),
+			After:      string(e.After),
+			Detail:     string(e.Detail),
+			CreatedAt:  e.CreatedAt.Format(time.RFC3339Nano),
+		})
+	}
+	return resp, nil
+}
+
+// parseAuditTime разбирает границу выборки в RFC 3339, пустая — без границы
+func parseAuditTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %w", apperrors.ErrInvalidParameter.With("name", name), err)
+	}
+	return t, nil
+}
@@ -24,22 +24,34 @@ func NewTasksCache(redis *redis.Client) *TasksCache {
 	}
 }
 
-// функция чистки ключей задач
+// ключ задачи и ключ множества id задач проекта. Множество проекта пополняется
+// при записи задачи, устаревшие id (задача удалена или перенесена) убираются при чтении.
+func taskKey(id int) string {
+	return fmt.Sprintf("task:%d", id)
+}
+
+func projectKey(id int) string {
+	return fmt.Sprintf("project:%d:tasks", id)
+}
+
+// функция чистки ключей задач и проектов
 func (s *TasksCache) ClearTaskCache(ctx context.Context) {
 
-	iter := s.redis.Scan(ctx, 0, "task:*", 0).Iterator()
-	for iter.Next(ctx) {
-		if err := s.redis.Del(ctx, iter.Val()).Err(); err != nil {
-			log.Printf("failed to delete cache key %s: %v", iter.Val(), err)
+	for _, pattern := range []string{"task:*", "project:*"} {
+		iter := s.redis.Scan(ctx, 0, pattern, 0).Iterator()
+		for iter.Next(ctx) {
+			if err := s.redis.Del(ctx, iter.Val()).Err(); err != nil {
+				log.Printf("failed to delete cache key %s: %v", iter.Val(), err)
+			}
+		}
+		if err := iter.Err(); err != nil {
+			log.Printf("redis scan error: %v", err)
 		}
-	}
-	if err := iter.Err(); err != nil {
-		log.Printf("redis scan error: %v", err)
 	}
 }
 func (s *TasksCache) GetTaskCache(ctx context.Context, id int) (*models.Task, error) {
 
-	key := fmt.Sprintf("task:%d", id)
+	key := taskKey(id)
 
 	val, err := s.redis.Get(ctx, key).Result()
 
@@ -62,7 +74,7 @@ func (s *TasksCache) GetTaskCache(ctx context.Context, id int) (*models.Task, er
 
 func (s *TasksCache) SetTaskCache(ctx context.Context, id int, task *models.Task) error {
 
-	key := fmt.Sprintf("task:%d", id)
+	key := taskKey(id)
 
 	data, err := json.Marshal(task)
 	if err != nil {
@@ -71,22 +83,31 @@ func (s *TasksCache) SetTaskCache(ctx context.Context, id int, task *models.Task
 	if err := s.redis.Set(ctx, key, data, 0).Err(); err != nil {
 		return fmt.Errorf("%w: %w: task id=%d, key=%s", apperrors.ErrSetTaskCache, err, id, key)
 	}
+	if err := s.addToProject(ctx, task); err != nil {
+		return fmt.Errorf("%w: %w: task id=%d", apperrors.ErrSetTaskCache, err, id)
+	}
 	return nil
 }
 
-func (s *TasksCache) GetTasksCache(ctx context.Context, limit int, search string) ([]*models.Task, error) {
+// GetTasksCache выбирает задачи из кэша по фильтру. Лимит применяется после
+// сортировки, иначе порядок зависел бы от порядка ключей в SCAN.
+func (s *TasksCache) GetTasksCache(ctx context.Context, filter models.TaskFilter) ([]*models.Task, error) {
 
 	var tasks []*models.Task
-	iter := s.redis.Scan(ctx, 0, "task:*", 0).Iterator()
-
-	for iter.Next(ctx) {
-		if len(tasks) >= limit && limit > 0 {
-			break
-		}
+	search := strings.TrimSpace(filter.Search)
+	keys, err := s.taskKeys(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w: filter=%+v", apperrors.ErrGetTasksCache, err, filter)
+	}
 
-		data, err := s.redis.Get(ctx, iter.Val()).Result()
+	for _, key := range keys {
+		data, err := s.redis.Get(ctx, key).Result()
 		if err != nil {
-			log.Printf("failed get task %s: %v", iter.Val(), err)
+			if errors.Is(err, redis.Nil) {
+				s.removeFromProject(ctx, filter.ProjectID, key)
+				continue
+			}
+			log.Printf("failed get task %s: %v", key, err)
 			continue
 		}
 
@@ -97,6 +118,14 @@ func (s *TasksCache) GetTasksCache(ctx context.Context, limit int, search string
 			continue
 		}
 
+		if !filter.MatchProject(task) {
+			s.removeFromProject(ctx, filter.ProjectID, key)
+			continue
+		}
+		if !filter.MatchPriority(task) || !filter.MatchStatus(task) || !filter.MatchTags(task) || !filter.MatchParent(task) {
+			continue
+		}
+
 		switch {
 		case search == "":
 			tasks = append(tasks, task)
@@ -123,13 +152,53 @@ func (s *TasksCache) GetTasksCache(ctx context.Context, limit int, search string
 		}
 
 	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("%w: no tasks found with filter=%+v", apperrors.ErrTaskNotFound, filter)
+	}
+	return filter.Sort(tasks), nil
+}
+
+// taskKeys ключи задач для фильтра: для проекта — из его множества, иначе все задачи через SCAN
+func (s *TasksCache) taskKeys(ctx context.Context, filter models.TaskFilter) ([]string, error) {
+	var keys []string
+	if filter.ProjectID != 0 {
+		ids, err := s.redis.SMembers(ctx, projectKey(filter.ProjectID)).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			keys = append(keys, "task:"+id)
+		}
+		return keys, nil
+	}
+
+	iter := s.redis.Scan(ctx, 0, "task:*", 0).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
 	if err := iter.Err(); err != nil {
-		return nil, fmt.Errorf("%w: %w: limit=%d, search=%s", apperrors.ErrGetTasksCache, err, limit, search)
+		return nil, err
 	}
-	if len(tasks) == 0 {
-		return nil, fmt.Errorf("%w: no tasks found with limit=%d, search=%s", apperrors.ErrTaskNotFound, limit, search)
+	return keys, nil
+}
+
+// addToProject добавляет id задачи в множество ее проекта
+func (s *TasksCache) addToProject(ctx context.Context, task *models.Task) error {
+	if task.ProjectID == nil {
+		return nil
+	}
+	return s.redis.SAdd(ctx, projectKey(*task.ProjectID), task.ID).Err()
+}
+
+// removeFromProject убирает устаревший id задачи из множества проекта
+func (s *TasksCache) removeFromProject(ctx context.Context, projectID int, key string) {
+	if projectID == 0 {
+		return
+	}
+	id := strings.TrimPrefix(key, "task:")
+	if err := s.redis.SRem(ctx, projectKey(projectID), id).Err(); err != nil {
+		log.Printf("failed to remove task %s from project %d cache: %v", id, projectID, err)
 	}
-	return tasks, nil
 }
 
 func isDateSearch(s string) bool {
@@ -140,7 +209,7 @@ func isDateSearch(s string) bool {
 func (s *TasksCache) SetTasksCache(ctx context.Context, tasks []*models.Task) error {
 
 	for _, task := range tasks {
-		key := fmt.Sprintf("task:%d", task.ID)
+		key := taskKey(task.ID)
 		tasksData, err := json.Marshal(task)
 		if err != nil {
 			return fmt.Errorf("%w: %w: task id=%d, key=%s", apperrors.ErrSetTasksCache, err, task.ID, key)
@@ -148,6 +217,9 @@ func (s *TasksCache) SetTasksCache(ctx context.Context, tasks []*models.Task) er
 		if err := s.redis.Set(ctx, key, tasksData, 0).Err(); err != nil {
 			return fmt.Errorf("%w: %w: task id=%d, key=%s", apperrors.ErrSetTasksCache, err, task.ID, key)
 		}
+		if err := s.addToProject(ctx, task); err != nil {
+			return fmt.Errorf("%w: %w: task id=%d", apperrors.ErrSetTasksCache, err, task.ID)
+		}
 
 	}
 	return nil
@@ -155,7 +227,7 @@ func (s *TasksCache) SetTasksCache(ctx context.Context, tasks []*models.Task) er
 
 func (s *TasksCache) DeleteTaskCache(ctx context.Context, id int) {
 
-	key := fmt.Sprintf("task:%d", id)
+	key := taskKey(id)
 	if err := s.redis.Del(ctx, key).Err(); err != nil {
 		log.Printf("%v: task id=%d, key=%s: %v", apperrors.ErrDeleteTaskCache, id, key, err)
 	}
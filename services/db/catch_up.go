@@ -0,0 +1,213 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	cm "github.com/Vasya-lis/firstWorkWithgRPC/common"
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/services/db/repo"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// catchesUp нужно ли сохранять пропущенные повторения задачи, у отмененной серии повторений нет
+func catchesUp(task *md.Task) bool {
+	return task.Repeat != "" &&
+		task.Status != md.StatusCancelled &&
+		task.RepeatMode != cm.RepeatModeCompletion &&
+		(task.CatchUp == cm.CatchUpMaterialize || task.CatchUp == cm.CatchUpHistory)
+}
+
+// missedFrom повторение серии, после которого считаются пропущенные. У отложенной задачи
+// это последнее повторение не позже отложенной даты: повторения внутри отсрочки
+// пользователь отложил сам, пропущенными они не считаются и счетчик не расходуют.
+func missedFrom(task *md.Task) (string, error) {
+	if task.Anchor == "" {
+		return task.Date, nil
+	}
+	snoozed, err := time.Parse(cm.FormDate, task.Date)
+	if err != nil {
+		return "", err
+	}
+	window, _, err := cm.MissedOccurrences(task.Anchor, snoozed, task.Repeat, 0, task.Exceptions)
+	if err != nil {
+		return "", err
+	}
+	if len(window) > 0 {
+		return window[len(window)-1], nil
+	}
+	return task.Anchor, nil
+}
+
+// recordMissed создает разовые задачи или записи истории для пропущенных дат
+// в транзакции tx, новые задачи попадают в кэш после фиксации
+func (s *TasksService) recordMissed(ctx context.Context, tx *repo.TasksRepo, task *md.Task, missed []string, changes *cacheUpdate) error {
+	if len(missed) == 0 {
+		return nil
+	}
+
+	switch task.CatchUp {
+	case cm.CatchUpMaterialize:
+		for _, date := range missed {
+			// просроченная дата сохраняется как есть, без нормализации к сегодняшнему дню
+			oneOff := &md.Task{
+				Date:      date,
+				Time:      task.Time,
+				TZ:        task.TZ,
+				Title:     task.Title,
+				Comment:   task.Comment,
+				Priority:  task.Priority,
+				Status:    md.StatusTodo,
+				Tags:      task.Tags,
+				ProjectID: task.ProjectID,
+				ParentID:  task.ParentID,
+				Checklist: task.Checklist.Reset(),
+			}
+			id, err := tx.AddTask(ctx, oneOff)
+			if err != nil {
+				return fmt.Errorf("%w: %w", apperrors.ErrCatchUp, err)
+			}
+			if err := tx.AddStatusChange(ctx, id, md.StatusUnspecified, oneOff.Status, s.clock.Now()); err != nil {
+				return err
+			}
+			if err := audit(ctx, tx, md.AuditAdd, id, nil, oneOff); err != nil {
+				return err
+			}
+			changes.set = append(changes.set, oneOff)
+		}
+	case cm.CatchUpHistory:
+		records := make([]*md.MissedOccurrence, 0, len(missed))
+		for _, date := range missed {
+			records = append(records, &md.MissedOccurrence{
+				TaskID: task.ID,
+				Date:   date,
+				Title:  task.Title,
+			})
+		}
+		if err := tx.AddMissed(ctx, records); err != nil {
+			return fmt.Errorf("%w: %w", apperrors.ErrCatchUp, err)
+		}
+	}
+	return nil
+}
+
+// CatchUp обрабатывает просроченные повторяющиеся задачи: все повторения до вчерашнего
+// дня включительно сохраняются по политике задачи, задача переносится на ближайшую дату
+func (s *TasksService) CatchUp(ctx context.Context) error {
+	tasks, err := s.tr.Tasks(ctx, md.TaskFilter{})
+	if err != nil {
+		return fmt.Errorf("%w: %w", apperrors.ErrCatchUp, err)
+	}
+
+	for _, task := range tasks {
+		if !catchesUp(task) {
+			continue
+		}
+		if err := s.catchUpTask(ctx, task.ID); err != nil {
+			log.Printf("%v: task id=%d: %v", apperrors.ErrCatchUp, task.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *TasksService) catchUpTask(ctx context.Context, id int) error {
+	changes := &cacheUpdate{}
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		// перечитываем под блокировкой: задачу могли выполнить после чтения списка
+		task, err := tx.GetTaskForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !catchesUp(task) {
+			return nil
+		}
+
+		now, err := cm.NowIn(s.clock.Now(), task.TZ)
+		if err != nil {
+			return err
+		}
+		yesterday := now.AddDate(0, 0, -1)
+
+		// текущее повторение еще не прошло, у отложенной задачи — отложенная дата
+		currentDate, err := time.Parse(cm.FormDate, task.Date)
+		if err != nil {
+			return err
+		}
+		if cm.AfterNow(currentDate, yesterday) {
+			return nil
+		}
+
+		// пропущено само текущее повторение и повторения серии после него,
+		// каждое расходует счетчик count
+		base, err := missedFrom(task)
+		if err != nil {
+			return err
+		}
+		later, remaining, err := cm.MissedOccurrences(base, yesterday, task.Repeat, task.Remaining, task.Exceptions)
+		if err != nil {
+			return err
+		}
+		missed := append([]string{task.Date}, later...)
+		last := base
+		if len(later) > 0 {
+			last = later[len(later)-1]
+		}
+
+		// ближайшее повторение начиная с сегодняшнего дня, после последнего пропущенного
+		next, left, finished, err := cm.NextOccurrence(yesterday, last, task.Repeat, cm.RepeatModeSchedule, remaining, task.Exceptions)
+		if err != nil {
+			return err
+		}
+
+		before := task.Clone()
+		if err := s.recordMissed(ctx, tx, task, missed, changes); err != nil {
+			return err
+		}
+		if finished {
+			if err := advance(ctx, tx, task, next, left, finished, changes); err != nil {
+				return err
+			}
+			return audit(ctx, tx, md.AuditCatchUp, id, before, nil)
+		}
+		if err := saveRevision(ctx, tx, task); err != nil {
+			return err
+		}
+		if err := advance(ctx, tx, task, next, left, finished, changes); err != nil {
+			return err
+		}
+		if err := refreshTask(ctx, tx, task); err != nil {
+			return err
+		}
+		return audit(ctx, tx, md.AuditCatchUp, id, before, task)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.applyCache(ctx, changes)
+	return nil
+}
+
+// RunCatchUp периодически запускает CatchUp до отмены контекста
+func (s *TasksService) RunCatchUp(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.CatchUp(ctx); err != nil {
+				log.Printf("%v", err)
+			}
+		}
+	}
+}
+
+// ListMissed история пропущенных повторений задачи
+func (s *TasksService) ListMissed(ctx context.Context, id int) ([]*md.MissedOccurrence, error) {
+	return s.tr.Missed(ctx, id)
+}
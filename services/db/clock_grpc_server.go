@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"time"
+
+	cm "github.com/Vasya-lis/firstWorkWithgRPC/common"
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	"google.golang.org/grpc/metadata"
+)
+
+// ключ метаданных с токеном админских методов
+const adminTokenKey = "x-admin-token"
+
+// checkAdmin пропускает только запросы с верным токеном, без токена в конфиге методы отключены
+func (s *TaskServer) checkAdmin(ctx context.Context) error {
+	if s.adminToken == "" {
+		return toStatus(apperrors.ErrAdminDisabled)
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	tokens := md.Get(adminTokenKey)
+	if len(tokens) == 0 || subtle.ConstantTimeCompare([]byte(tokens[0]), []byte(s.adminToken)) != 1 {
+		return toStatus(apperrors.ErrAdminToken)
+	}
+	return nil
+}
+
+func (s *TaskServer) clockResponse() *pb.ClockResponse {
+	return &pb.ClockResponse{
+		Now:    s.clock.Now().Format(time.RFC3339),
+		Frozen: s.clock.Frozen(),
+	}
+}
+
+// GetClock возвращает текущее время сервиса
+func (s *TaskServer) GetClock(ctx context.Context, req *pb.EmptyRequest) (*pb.ClockResponse, error) {
+	if err := s.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return s.clockResponse(), nil
+}
+
+// SetClock замораживает или размораживает часы сервиса
+func (s *TaskServer) SetClock(ctx context.Context, req *pb.SetClockRequest) (*pb.ClockResponse, error) {
+	if err := s.checkAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.FrozenTime == "" {
+		s.clock.Unfreeze()
+		log.Println("clock unfrozen")
+		return s.clockResponse(), nil
+	}
+
+	t, err := cm.ParseClockTime(req.FrozenTime)
+	if err != nil {
+		return nil, toStatus(fmt.Errorf("%w: %w", apperrors.ErrInvalidParameter.With("name", "frozen_time"), err))
+	}
+	s.clock.Freeze(t)
+	log.Printf("clock frozen at %s", t.Format(time.RFC3339))
+	return s.clockResponse(), nil
+}
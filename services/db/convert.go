@@ -0,0 +1,206 @@
+package db
+
+import (
+	"time"
+
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	"github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// taskToProto переводит модель задачи в прото буф
+func taskToProto(t *models.Task) *pb.Task {
+	return &pb.Task{
+		Id:         int32(t.ID),
+		Date:       t.Date,
+		Title:      t.Title,
+		Comment:    t.Comment,
+		Repeat:     t.Repeat,
+		Time:       t.Time,
+		Tz:         t.TZ,
+		Remaining:  int32(t.Remaining),
+		RepeatMode: t.RepeatMode,
+		Anchor:     t.Anchor,
+		Exceptions: t.Exceptions,
+		CatchUp:    t.CatchUp,
+		Priority:   pb.Priority(t.Priority),
+		Status:     pb.Status(t.Status),
+		Tags:       t.Tags,
+		ProjectId:  refToProto(t.ProjectID),
+		ParentId:   refToProto(t.ParentID),
+		Checklist:  checklistToProto(t.Checklist),
+		DependsOn:  idsToProto(t.DependsOn),
+		Blocked:    t.Blocked,
+		CreatedAt:  timeToProto(t.CreatedAt),
+		UpdatedAt:  timeToProto(t.UpdatedAt),
+	}
+}
+
+// taskFromProto переводит прото буф в модель задачи
+func taskFromProto(t *pb.Task) *models.Task {
+	return &models.Task{
+		ID:         int(t.Id),
+		Date:       t.Date,
+		Title:      t.Title,
+		Comment:    t.Comment,
+		Repeat:     t.Repeat,
+		Time:       t.Time,
+		TZ:         t.Tz,
+		Remaining:  int(t.Remaining),
+		RepeatMode: t.RepeatMode,
+		Anchor:     t.Anchor,
+		Exceptions: t.Exceptions,
+		CatchUp:    t.CatchUp,
+		Priority:   models.Priority(t.Priority),
+		Status:     models.Status(t.Status),
+		Tags:       t.Tags,
+		ProjectID:  refFromProto(t.ProjectId),
+		ParentID:   refFromProto(t.ParentId),
+		Checklist:  checklistFromProto(t.Checklist),
+		DependsOn:  idsFromProto(t.DependsOn),
+		Blocked:    t.Blocked,
+		CreatedAt:  timeFromProto(t.CreatedAt),
+		UpdatedAt:  timeFromProto(t.UpdatedAt),
+	}
+}
+
+// tagToProto переводит метку в прото буф
+func tagToProto(t *models.Tag) *pb.Tag {
+	return &pb.Tag{
+		Id:        int32(t.ID),
+		Name:      t.Name,
+		TaskCount: int32(t.TaskCount),
+	}
+}
+
+// refToProto переводит необязательную ссылку (проект, родитель) в id, 0 — ссылки нет
+func refToProto(id *int) int32 {
+	if id == nil {
+		return 0
+	}
+	return int32(*id)
+}
+
+// refFromProto переводит id в необязательную ссылку, 0 — ссылки нет
+func refFromProto(id int32) *int {
+	if id == 0 {
+		return nil
+	}
+	v := int(id)
+	return &v
+}
+
+// projectToProto переводит проект в прото буф
+func projectToProto(p *models.Project) *pb.Project {
+	return &pb.Project{
+		Id:          int32(p.ID),
+		Name:        p.Name,
+		Description: p.Description,
+		Archived:    p.Archived,
+		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
+		TaskCount:   int32(p.TaskCount),
+	}
+}
+
+// projectFromProto переводит прото буф в модель проекта, счетчик и дата задаются базой
+func projectFromProto(p *pb.Project) *models.Project {
+	return &models.Project{
+		ID:          int(p.Id),
+		Name:        p.Name,
+		Description: p.Description,
+		Archived:    p.Archived,
+	}
+}
+
+// projectStatsToProto переводит сводку по проекту в прото буф
+func projectStatsToProto(s *models.ProjectStats) *pb.ProjectStats {
+	return &pb.ProjectStats{
+		ProjectId:    int32(s.ProjectID),
+		Total:        int32(s.Total),
+		Overdue:      int32(s.Overdue),
+		DueToday:     int32(s.DueToday),
+		Upcoming:     int32(s.Upcoming),
+		HighPriority: int32(s.HighPriority),
+		Repeating:    int32(s.Repeating),
+		Cancelled:    int32(s.Cancelled),
+	}
+}
+
+// checklistToProto переводит чек-лист в прото буф
+func checklistToProto(c models.Checklist) []*pb.ChecklistItem {
+	var items []*pb.ChecklistItem
+	for _, item := range c {
+		items = append(items, &pb.ChecklistItem{Title: item.Title, Done: item.Done})
+	}
+	return items
+}
+
+// checklistFromProto переводит прото буф в чек-лист
+func checklistFromProto(items []*pb.ChecklistItem) models.Checklist {
+	var c models.Checklist
+	for _, item := range items {
+		c = append(c, models.ChecklistItem{Title: item.Title, Done: item.Done})
+	}
+	return c
+}
+
+// idsToProto переводит список id в прото буф
+func idsToProto(ids []int) []int32 {
+	var out []int32
+	for _, id := range ids {
+		out = append(out, int32(id))
+	}
+	return out
+}
+
+// idsFromProto переводит список id из прото буфа
+func idsFromProto(ids []int32) []int {
+	var out []int
+	for _, id := range ids {
+		out = append(out, int(id))
+	}
+	return out
+}
+
+// timeToProto переводит время в RFC 3339, нулевое — пустая строка
+func timeToProto(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// timeFromProto разбирает время в RFC 3339, пустое или неверное — нулевое
+func timeFromProto(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+// revisionToProto переводит версию задачи в прото буф, снимок передается как задача
+func revisionToProto(r *models.TaskRevision) *pb.TaskRevision {
+	task := &models.Task{ID: r.TaskID}
+	r.Snapshot.Apply(task)
+	resp := &pb.TaskRevision{
+		Id:        int32(r.ID),
+		TaskId:    int32(r.TaskID),
+		Number:    int32(r.Number),
+		Snapshot:  taskToProto(task),
+		CreatedAt: timeToProto(r.CreatedAt),
+	}
+	for _, c := range r.Changes {
+		resp.Changes = append(resp.Changes, &pb.FieldChange{Field: c.Field, Old: string(c.Old), New: string(c.New)})
+	}
+	return resp
+}
+
+// attachmentToProto переводит метаданные вложения в прото буф, ключ хранилища наружу не отдается
+func attachmentToProto(a *models.Attachment) *pb.Attachment {
+	return &pb.Attachment{
+		Id:          int32(a.ID),
+		TaskId:      int32(a.TaskID),
+		Name:        a.Name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Sha256:      a.SHA256,
+		CreatedAt:   timeToProto(a.CreatedAt),
+	}
+}
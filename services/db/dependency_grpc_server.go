@@ -0,0 +1,25 @@
+package db
+
+import (
+	"context"
+
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+)
+
+// AddDependency добавляет зависимость между задачами, цикл отклоняется
+func (s *TaskServer) AddDependency(ctx context.Context, req *pb.DependencyRequest) (*pb.Task, error) {
+	task, err := s.ts.AddDependency(ctx, int(req.TaskId), int(req.DependsOnId))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return taskToProto(task), nil
+}
+
+// RemoveDependency удаляет зависимость между задачами
+func (s *TaskServer) RemoveDependency(ctx context.Context, req *pb.DependencyRequest) (*pb.Task, error) {
+	task, err := s.ts.RemoveDependency(ctx, int(req.TaskId), int(req.DependsOnId))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return taskToProto(task), nil
+}
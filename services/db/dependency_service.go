@@ -0,0 +1,132 @@
+package db
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/services/db/repo"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// AddDependency добавляет зависимость: задача taskID ждет выполнения dependsOnID.
+// Зависимость, замыкающая цикл, отклоняется. Возвращает задачу taskID.
+func (s *TasksService) AddDependency(ctx context.Context, taskID, dependsOnID int) (*md.Task, error) {
+	if taskID == dependsOnID {
+		return nil, apperrors.NewFieldError("depends_on_id", apperrors.ErrDependencyCycle)
+	}
+	return s.changeDependency(ctx, taskID, md.AuditAddDependency, func(tx *repo.TasksRepo) error {
+		if _, err := tx.GetTask(ctx, dependsOnID); err != nil {
+			return err
+		}
+		// цикл появится, если dependsOnID уже зависит от taskID
+		cycle, err := tx.DependsOnPath(ctx, dependsOnID, taskID)
+		if err != nil {
+			return err
+		}
+		if cycle {
+			return apperrors.NewFieldError("depends_on_id", apperrors.ErrDependencyCycle)
+		}
+		return tx.AddDependency(ctx, taskID, dependsOnID)
+	})
+}
+
+// RemoveDependency удаляет зависимость, возвращает задачу taskID
+func (s *TasksService) RemoveDependency(ctx context.Context, taskID, dependsOnID int) (*md.Task, error) {
+	return s.changeDependency(ctx, taskID, md.AuditRemoveDependency, func(tx *repo.TasksRepo) error {
+		return tx.RemoveDependency(ctx, taskID, dependsOnID)
+	})
+}
+
+// changeDependency меняет граф под блокировкой и перечитывает задачу для ответа и кэша.
+// Изменение пишется в журнал как action, повторное добавление или удаление — нет.
+func (s *TasksService) changeDependency(ctx context.Context, taskID int, action string, change func(tx *repo.TasksRepo) error) (*md.Task, error) {
+	var task *md.Task
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		if err := tx.LockDependencies(ctx); err != nil {
+			return err
+		}
+		before, err := tx.GetTask(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		if err := change(tx); err != nil {
+			return err
+		}
+		task, err = tx.GetTask(ctx, taskID)
+		if err != nil || slices.Equal(before.DependsOn, task.DependsOn) {
+			return err
+		}
+		return audit(ctx, tx, action, taskID, before, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.applyCache(ctx, &cacheUpdate{set: []*md.Task{task}})
+	if err := s.markBlocked(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// markBlocked выставляет признак blocked по текущему состоянию зависимостей.
+// Признак не хранится и в кэше не доверяется: зависимость могли выполнить после записи в кэш.
+func (s *TasksService) markBlocked(ctx context.Context, tasks ...*md.Task) error {
+	var ids []int
+	for _, task := range tasks {
+		task.Blocked = false
+		ids = append(ids, task.DependsOn...)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	slices.Sort(ids)
+	prereqs, err := s.tr.Prerequisites(ctx, slices.Compact(ids))
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		task.Blocked = len(blockers(task, prereqs)) > 0
+	}
+	return nil
+}
+
+// blockers id невыполненных задач из зависимостей task
+func blockers(task *md.Task, prereqs []*md.Task) []int {
+	var ids []int
+	for _, p := range prereqs {
+		if slices.Contains(task.DependsOn, p.ID) && task.BlockedBy(p) {
+			ids = append(ids, p.ID)
+		}
+	}
+	return ids
+}
+
+// checkBlocked отклоняет выполнение задачи с невыполненными зависимостями
+func checkBlocked(ctx context.Context, tx *repo.TasksRepo, task *md.Task) error {
+	prereqs, err := tx.Prerequisites(ctx, task.DependsOn)
+	if err != nil {
+		return err
+	}
+	ids := blockers(task, prereqs)
+	if len(ids) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(ids))
+	for _, id := range ids {
+		names = append(names, strconv.Itoa(id))
+	}
+	return apperrors.ErrTaskBlocked.With("ids", strings.Join(names, ", "))
+}
+
+// reloadDependents обновляет в кэше задачи, зависевшие от удаленных: ребра удалены каскадно
+func reloadDependents(ctx context.Context, tx *repo.TasksRepo, dependents, deleted []int, changes *cacheUpdate) error {
+	dependents = slices.DeleteFunc(dependents, func(id int) bool {
+		return slices.Contains(deleted, id)
+	})
+	_, err := reloadTasks(ctx, tx, dependents, changes)
+	return err
+}
@@ -0,0 +1,37 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+func TestAddDependencySelf(t *testing.T) {
+	// зависимость задачи от самой себя отклоняется до обращения к базе
+	s := &TasksService{}
+	_, err := s.AddDependency(context.Background(), 5, 5)
+	if !errors.Is(err, apperrors.ErrDependencyCycle) {
+		t.Fatalf("AddDependency() error = %v, want ErrDependencyCycle", err)
+	}
+	if fields := apperrors.FieldErrors(err); len(fields) != 1 || fields[0].Field != "depends_on_id" {
+		t.Errorf("AddDependency() field errors = %v, want depends_on_id", fields)
+	}
+}
+
+func TestBlockers(t *testing.T) {
+	task := &md.Task{ID: 1, Date: "20240126", DependsOn: []int{2, 3, 4, 5}}
+	prereqs := []*md.Task{
+		{ID: 2, Date: "20240101"},                // разовая, не выполнена
+		{ID: 3, Date: "20240120", Repeat: "d 7"}, // повторение раньше задачи
+		{ID: 4, Date: "20240126", Repeat: "d 7"}, // в тот же день
+		{ID: 5, Date: "20240127", Repeat: "d 7"}, // уже после задачи
+		{ID: 6, Date: "20240101"},                // не зависимость задачи
+	}
+	if got, want := blockers(task, prereqs), []int{2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("blockers() = %v, want %v", got, want)
+	}
+}
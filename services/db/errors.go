@@ -0,0 +1,89 @@
+package db
+
+import (
+	"log"
+	"strings"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// домен ErrorInfo, по нему клиент отличает коды db-сервиса
+const errorDomain = "scheduler.db"
+
+// grpcCodes соответствие класса ошибки коду gRPC
+var grpcCodes = map[apperrors.Kind]codes.Code{
+	apperrors.KindInvalid:   codes.InvalidArgument,
+	apperrors.KindNotFound:  codes.NotFound,
+	apperrors.KindConflict:  codes.FailedPrecondition,
+	apperrors.KindForbidden: codes.PermissionDenied,
+	apperrors.KindInternal:  codes.Internal,
+
+	apperrors.KindMethodNotAllowed: codes.Unimplemented,
+}
+
+// toStatus единообразно переводит ошибку сервиса в статус gRPC:
+// код по классу из apperrors, стабильный код ошибки и параметры сообщения
+// в ErrorInfo, нарушения полей в BadRequest. Текст внутренних ошибок только логируется.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if invalid := invalidFields(err); invalid != nil {
+		return invalid
+	}
+
+	code := grpcCodes[apperrors.KindOf(err)]
+	msg := err.Error()
+	if code == codes.Internal {
+		log.Println("error: ", err)
+		msg = apperrors.ErrInternal.Error()
+	}
+	return withDetails(status.New(code, msg), apperrors.Code(err), apperrors.ParamsOf(err))
+}
+
+// invalidFields переводит ошибки проверки полей в InvalidArgument с деталями BadRequest,
+// nil — если ошибок полей нет. Код нарушения передается в Reason, параметры его
+// сообщения — в ErrorInfo с ключами вида "поле.параметр".
+func invalidFields(err error) error {
+	fields := apperrors.FieldErrors(err)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(fields))
+	metadata := make(map[string]string)
+	badRequest := &errdetails.BadRequest{}
+	for _, f := range fields {
+		names = append(names, f.Field)
+		for k, v := range apperrors.ParamsOf(f.Err) {
+			metadata[f.Field+"."+k] = v
+		}
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       f.Field,
+			Description: f.Err.Error(),
+			Reason:      apperrors.Code(f.Err),
+		})
+	}
+
+	invalid := apperrors.ErrInvalidFields.With("fields", strings.Join(names, ", "))
+	metadata["fields"] = invalid.Params["fields"]
+	st := status.New(codes.InvalidArgument, invalid.Error())
+	return withDetails(st, invalid.Code, metadata, badRequest)
+}
+
+// withDetails добавляет к статусу ErrorInfo с кодом ошибки и параметрами и прочие детали
+func withDetails(st *status.Status, code string, params map[string]string, details ...protoadapt.MessageV1) error {
+	info := &errdetails.ErrorInfo{Reason: code, Domain: errorDomain, Metadata: params}
+	detailed, err := st.WithDetails(append([]protoadapt.MessageV1{info}, details...)...)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+
+	"github.com/Vasya-lis/firstWorkWithgRPC/common/validate"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// validateUnary проверяет запрос по ограничениям полей из proto до вызова обработчика.
+// Имена меток сначала приводятся к хранимому виду, иначе " Home" не прошло бы шаблон.
+func validateUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	normalizeRequest(req)
+	// в частичном обновлении проверяются только переданные поля (без fields — поля
+	// старого API), задачу после слияния с сохраненной проверяет сервис
+	if r, ok := req.(*pb.UpdateTaskRequest); ok {
+		fields := r.Fields
+		if len(fields) == 0 {
+			fields = legacyFields
+		}
+		if err := invalidFields(validate.Partial(r, "task.", fields)); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+	if m, ok := req.(proto.Message); ok {
+		if err := invalidFields(validate.Message(m)); err != nil {
+			return nil, err
+		}
+	}
+	return handler(ctx, req)
+}
+
+// validateStream проверяет каждое сообщение потока клиента по ограничениям из proto
+func validateStream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &validatingStream{ServerStream: ss})
+}
+
+type validatingStream struct {
+	grpc.ServerStream
+}
+
+func (s *validatingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if msg, ok := m.(proto.Message); ok {
+		if err := invalidFields(validate.Message(msg)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// normalizeRequest приводит имена меток в запросе к хранимому виду
+func normalizeRequest(req any) {
+	switch r := req.(type) {
+	case *pb.Task:
+		r.Tags = normalizeTags(r.Tags)
+	case *pb.UpdateTaskRequest:
+		if r.Task != nil {
+			r.Task.Tags = normalizeTags(r.Task.Tags)
+		}
+	case *pb.CreateTagRequest:
+		r.Name = tagName(r.Name)
+	case *pb.RenameTagRequest:
+		r.Name = tagName(r.Name)
+	}
+}
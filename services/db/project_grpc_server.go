@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+)
+
+// ListProjects возвращает проекты с числом задач
+func (s *TaskServer) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb.ListProjectsResponse, error) {
+	projects, err := s.ts.ListProjects(ctx, req.IncludeArchived)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListProjectsResponse{}
+	for _, p := range projects {
+		resp.Projects = append(resp.Projects, projectToProto(p))
+	}
+	return resp, nil
+}
+
+// GetProject возвращает проект по ID
+func (s *TaskServer) GetProject(ctx context.Context, req *pb.IDRequest) (*pb.Project, error) {
+	project, err := s.ts.GetProject(ctx, int(req.Id))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return projectToProto(project), nil
+}
+
+// CreateProject создает проект
+func (s *TaskServer) CreateProject(ctx context.Context, req *pb.Project) (*pb.Project, error) {
+	project, err := s.ts.CreateProject(ctx, projectFromProto(req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return projectToProto(project), nil
+}
+
+// UpdateProject меняет имя и описание проекта
+func (s *TaskServer) UpdateProject(ctx context.Context, req *pb.Project) (*pb.Project, error) {
+	project, err := s.ts.UpdateProject(ctx, projectFromProto(req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return projectToProto(project), nil
+}
+
+// ArchiveProject переносит проект в архив или возвращает из него
+func (s *TaskServer) ArchiveProject(ctx context.Context, req *pb.ArchiveProjectRequest) (*pb.Project, error) {
+	project, err := s.ts.ArchiveProject(ctx, int(req.Id), req.Archived)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return projectToProto(project), nil
+}
+
+// DeleteProject удаляет проект, задачи остаются без проекта
+func (s *TaskServer) DeleteProject(ctx context.Context, req *pb.IDRequest) (*pb.EmptyResponse, error) {
+	if err := s.ts.DeleteProject(ctx, int(req.Id)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.EmptyResponse{}, nil
+}
+
+// GetProjectStats возвращает сводку по задачам проекта
+func (s *TaskServer) GetProjectStats(ctx context.Context, req *pb.IDRequest) (*pb.ProjectStats, error) {
+	stats, err := s.ts.ProjectStats(ctx, int(req.Id))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return projectStatsToProto(stats), nil
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	cm "github.com/Vasya-lis/firstWorkWithgRPC/common"
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/services/db/repo"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// ListProjects возвращает проекты с числом задач, архивные — при includeArchived
+func (s *TasksService) ListProjects(ctx context.Context, includeArchived bool) ([]*md.Project, error) {
+	return s.tr.Projects(ctx, includeArchived)
+}
+
+// GetProject возвращает проект с числом задач
+func (s *TasksService) GetProject(ctx context.Context, id int) (*md.Project, error) {
+	return s.tr.GetProject(ctx, id)
+}
+
+// CreateProject создает проект, новый проект не бывает архивным
+func (s *TasksService) CreateProject(ctx context.Context, project *md.Project) (*md.Project, error) {
+	project.ID = 0
+	project.Archived = false
+	if err := s.tr.AddProject(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// UpdateProject меняет имя и описание проекта, архив меняется через ArchiveProject
+func (s *TasksService) UpdateProject(ctx context.Context, project *md.Project) (*md.Project, error) {
+	if project.ID <= 0 {
+		return nil, apperrors.NewFieldError("id", apperrors.ErrProjectNotFound)
+	}
+	if err := s.tr.UpdateProject(ctx, project); err != nil {
+		return nil, err
+	}
+	return s.tr.GetProject(ctx, project.ID)
+}
+
+// ArchiveProject переносит проект в архив или возвращает из него
+func (s *TasksService) ArchiveProject(ctx context.Context, id int, archived bool) (*md.Project, error) {
+	if err := s.tr.ArchiveProject(ctx, id, archived); err != nil {
+		return nil, err
+	}
+	return s.tr.GetProject(ctx, id)
+}
+
+// DeleteProject удаляет проект, его задачи остаются без проекта
+func (s *TasksService) DeleteProject(ctx context.Context, id int) error {
+	changes := &cacheUpdate{}
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		taskIDs, err := tx.TaskIDsByProject(ctx, id)
+		if err != nil {
+			return err
+		}
+		before, err := tx.TasksByIDs(ctx, taskIDs)
+		if err != nil {
+			return err
+		}
+		if err := tx.DeleteProject(ctx, id); err != nil {
+			return err
+		}
+		after, err := reloadTasks(ctx, tx, taskIDs, changes)
+		if err != nil {
+			return err
+		}
+		return auditTasks(ctx, tx, md.AuditDeleteProject, before, after)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.applyCache(ctx, changes)
+	return nil
+}
+
+// ProjectStats считает задачи проекта: просроченные, на сегодня, будущие, важные и повторяющиеся
+func (s *TasksService) ProjectStats(ctx context.Context, id int) (*md.ProjectStats, error) {
+	if _, err := s.tr.GetProject(ctx, id); err != nil {
+		return nil, err
+	}
+	today := s.clock.Now().Format(cm.FormDate)
+	return s.tr.ProjectStats(ctx, id, today)
+}
+
+// checkProject проверяет в транзакции tx, что задачу можно сохранить в проект:
+// он существует и не в архиве
+func checkProject(ctx context.Context, tx *repo.TasksRepo, projectID *int) error {
+	if projectID == nil {
+		return nil
+	}
+	project, err := tx.GetProject(ctx, *projectID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrProjectNotFound) {
+			return apperrors.NewFieldError("project_id", err)
+		}
+		return err
+	}
+	if project.Archived {
+		return apperrors.NewFieldError("project_id", apperrors.ErrProjectArchived)
+	}
+	return nil
+}
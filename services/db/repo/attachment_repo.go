@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AddAttachment сохраняет метаданные вложения, содержимое к этому моменту уже в хранилище
+func (t *TasksRepo) AddAttachment(ctx context.Context, attachment *md.Attachment) error {
+	if err := t.db.WithContext(ctx).Omit(clause.Associations).Create(attachment).Error; err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrAddAttachment, err)
+	}
+	return nil
+}
+
+// Attachments вложения задачи в порядке добавления
+func (t *TasksRepo) Attachments(ctx context.Context, taskID int) ([]*md.Attachment, error) {
+	if taskID <= 0 {
+		return nil, apperrors.ErrInvalidTaskID
+	}
+
+	var attachments []*md.Attachment
+	err := t.db.WithContext(ctx).Omit(clause.Associations).
+		Where("task_id = ?", taskID).
+		Order("id ASC").
+		Find(&attachments).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetAttachments, err)
+	}
+	return attachments, nil
+}
+
+// GetAttachment вложение по id
+func (t *TasksRepo) GetAttachment(ctx context.Context, id int) (*md.Attachment, error) {
+	var attachment md.Attachment
+	err := t.db.WithContext(ctx).Omit(clause.Associations).First(&attachment, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetAttachments, err)
+	}
+	return &attachment, nil
+}
+
+// DeleteAttachment удаляет метаданные вложения, содержимое удаляет сервис
+func (t *TasksRepo) DeleteAttachment(ctx context.Context, id int) error {
+	result := t.db.WithContext(ctx).Delete(&md.Attachment{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrDeleteAttachment, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return apperrors.ErrAttachmentNotFound
+	}
+	return nil
+}
+
+// AttachmentKeys ключи содержимого вложений задач, нужны до удаления задач:
+// строки вложений удаляет база каскадно
+func (t *TasksRepo) AttachmentKeys(ctx context.Context, taskIDs []int) ([]string, error) {
+	if len(taskIDs) == 0 {
+		return nil, nil
+	}
+
+	var keys []string
+	err := t.db.WithContext(ctx).Model(&md.Attachment{}).
+		Where("task_id IN ?", taskIDs).
+		Pluck("key", &keys).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetAttachments, err)
+	}
+	return keys, nil
+}
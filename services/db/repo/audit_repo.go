@@ -0,0 +1,53 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// AddAuditEvent добавляет запись в журнал, вызывается в транзакции изменения
+func (t *TasksRepo) AddAuditEvent(ctx context.Context, event *md.AuditEvent) error {
+	if err := t.db.WithContext(ctx).Create(event).Error; err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrAddAuditEvent, err)
+	}
+	return nil
+}
+
+// AuditEvents записи журнала по фильтру в порядке добавления
+func (t *TasksRepo) AuditEvents(ctx context.Context, filter md.AuditFilter) ([]*md.AuditEvent, error) {
+	query := t.db.WithContext(ctx).Model(&md.AuditEvent{})
+
+	if filter.TaskID != 0 {
+		query = query.Where("task_id = ?", filter.TaskID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.Actor != "" {
+		query = query.Where("actor = ?", filter.Actor)
+	}
+	if filter.RequestID != "" {
+		query = query.Where("request_id = ?", filter.RequestID)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("created_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("created_at < ?", filter.Until)
+	}
+	if filter.AfterID > 0 {
+		query = query.Where("id > ?", filter.AfterID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var events []*md.AuditEvent
+	if err := query.Order("id ASC").Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetAuditEvents, err)
+	}
+	return events, nil
+}
@@ -0,0 +1,132 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ключ advisory lock для изменений графа зависимостей: проверка цикла и вставка
+// ребра должны идти без параллельных изменений, иначе два ребра вместе дадут цикл
+const dependencyLockKey = 4601
+
+// loadRelations заполняет метки и зависимости задач
+func loadRelations(db *gorm.DB, tasks ...*md.Task) error {
+	if err := loadTags(db, tasks...); err != nil {
+		return err
+	}
+	return loadDependencies(db, tasks...)
+}
+
+// loadDependencies заполняет id задач, от которых зависят задачи
+func loadDependencies(db *gorm.DB, tasks ...*md.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	byID := make(map[int]*md.Task, len(tasks))
+	ids := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		task.DependsOn = nil
+		byID[task.ID] = task
+		ids = append(ids, task.ID)
+	}
+
+	var edges []md.TaskDependency
+	err := db.Omit(clause.Associations).
+		Where("task_id IN ?", ids).
+		Order("depends_on_id").
+		Find(&edges).Error
+	if err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrGetDependencies, err)
+	}
+	for _, edge := range edges {
+		byID[edge.TaskID].DependsOn = append(byID[edge.TaskID].DependsOn, edge.DependsOnID)
+	}
+	return nil
+}
+
+// LockDependencies блокирует изменения графа зависимостей до конца транзакции,
+// вызывается внутри InTx
+func (t *TasksRepo) LockDependencies(ctx context.Context) error {
+	if err := t.db.WithContext(ctx).Exec("SELECT pg_advisory_xact_lock(?)", dependencyLockKey).Error; err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrAddDependency, err)
+	}
+	return nil
+}
+
+// DependsOnPath проверяет, зависит ли задача from от задачи to напрямую или через другие задачи
+func (t *TasksRepo) DependsOnPath(ctx context.Context, from, to int) (bool, error) {
+	var found bool
+	err := t.db.WithContext(ctx).Raw(`WITH RECURSIVE reach AS (
+			SELECT depends_on_id FROM task_dependencies WHERE task_id = ?
+			UNION
+			SELECT task_dependencies.depends_on_id FROM task_dependencies
+			JOIN reach ON task_dependencies.task_id = reach.depends_on_id
+		)
+		SELECT EXISTS (SELECT 1 FROM reach WHERE depends_on_id = ?)`, from, to).Scan(&found).Error
+	if err != nil {
+		return false, fmt.Errorf("%w:%w", apperrors.ErrGetDependencies, err)
+	}
+	return found, nil
+}
+
+// AddDependency добавляет ребро, повторное добавление ничего не меняет
+func (t *TasksRepo) AddDependency(ctx context.Context, taskID, dependsOnID int) error {
+	edge := &md.TaskDependency{TaskID: taskID, DependsOnID: dependsOnID}
+	err := t.db.WithContext(ctx).Omit(clause.Associations).Clauses(clause.OnConflict{DoNothing: true}).Create(edge).Error
+	if err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrAddDependency, err)
+	}
+	return nil
+}
+
+// RemoveDependency удаляет ребро
+func (t *TasksRepo) RemoveDependency(ctx context.Context, taskID, dependsOnID int) error {
+	result := t.db.WithContext(ctx).
+		Where("task_id = ? AND depends_on_id = ?", taskID, dependsOnID).
+		Delete(&md.TaskDependency{})
+	if result.Error != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrRemoveDependency, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrDependencyNotFound
+	}
+	return nil
+}
+
+// Dependents id задач, которые зависят от любой из задач ids
+func (t *TasksRepo) Dependents(ctx context.Context, ids []int) ([]int, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var dependents []int
+	err := t.db.WithContext(ctx).Model(&md.TaskDependency{}).
+		Distinct("task_id").
+		Where("depends_on_id IN ?", ids).
+		Pluck("task_id", &dependents).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetDependencies, err)
+	}
+	return dependents, nil
+}
+
+// Prerequisites дата и правило повторения задач ids — этого достаточно, чтобы
+// понять, блокируют ли они зависимые задачи. Выполненных задач в ответе нет,
+// отмененные не блокируют и тоже пропускаются.
+func (t *TasksRepo) Prerequisites(ctx context.Context, ids []int) ([]*md.Task, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var tasks []*md.Task
+	err := t.db.WithContext(ctx).Select("id, date, repeat").
+		Where("id IN ? AND status <> ?", ids, md.StatusCancelled).
+		Find(&tasks).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetDependencies, err)
+	}
+	return tasks, nil
+}
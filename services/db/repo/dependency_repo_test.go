@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+func TestDependsOnPath(t *testing.T) {
+	r := testRepo(t)
+	ctx := context.Background()
+
+	// a ждет b, b ждет c и d
+	ids := make(map[string]int)
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		id, err := r.AddTask(ctx, &md.Task{Title: name, Date: "20240126"})
+		if err != nil {
+			t.Fatalf("AddTask(%s): %v", name, err)
+		}
+		ids[name] = id
+	}
+	for _, edge := range [][2]string{{"a", "b"}, {"b", "c"}, {"b", "d"}} {
+		if err := r.AddDependency(ctx, ids[edge[0]], ids[edge[1]]); err != nil {
+			t.Fatalf("AddDependency(%s, %s): %v", edge[0], edge[1], err)
+		}
+	}
+
+	cases := []struct {
+		from, to string
+		want     bool
+	}{
+		{"a", "b", true},
+		{"a", "c", true}, // через b
+		{"a", "d", true},
+		{"b", "a", false},
+		{"c", "a", false},
+		{"a", "e", false},
+		{"e", "a", false},
+		{"c", "c", false},
+	}
+	for _, tc := range cases {
+		got, err := r.DependsOnPath(ctx, ids[tc.from], ids[tc.to])
+		if err != nil {
+			t.Fatalf("DependsOnPath(%s, %s): %v", tc.from, tc.to, err)
+		}
+		if got != tc.want {
+			t.Errorf("DependsOnPath(%s, %s) = %v, want %v", tc.from, tc.to, got, tc.want)
+		}
+	}
+}
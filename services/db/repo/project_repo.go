@@ -0,0 +1,123 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+	"gorm.io/gorm"
+)
+
+// projectsWithCount проекты с числом задач
+func projectsWithCount(db *gorm.DB) *gorm.DB {
+	return db.Model(&md.Project{}).
+		Select("projects.*, COUNT(tasks.id) AS task_count").
+		Joins("LEFT JOIN tasks ON tasks.project_id = projects.id").
+		Group("projects.id")
+}
+
+// Projects список проектов по имени, архивные — только при includeArchived
+func (t *TasksRepo) Projects(ctx context.Context, includeArchived bool) ([]*md.Project, error) {
+	var projects []*md.Project
+	query := projectsWithCount(t.db.WithContext(ctx))
+	if !includeArchived {
+		query = query.Where("projects.archived = ?", false)
+	}
+	if err := query.Order("projects.name, projects.id").Find(&projects).Error; err != nil {
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetProjects, err)
+	}
+	if projects == nil {
+		projects = []*md.Project{}
+	}
+	return projects, nil
+}
+
+// GetProject проект по id с числом задач
+func (t *TasksRepo) GetProject(ctx context.Context, id int) (*md.Project, error) {
+	var project md.Project
+	err := projectsWithCount(t.db.WithContext(ctx)).Where("projects.id = ?", id).Take(&project).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrProjectNotFound
+		}
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetProjects, err)
+	}
+	return &project, nil
+}
+
+// AddProject создает проект
+func (t *TasksRepo) AddProject(ctx context.Context, project *md.Project) error {
+	if err := t.db.WithContext(ctx).Omit("Tasks").Create(project).Error; err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrAddProject, err)
+	}
+	return nil
+}
+
+// UpdateProject меняет имя и описание проекта
+func (t *TasksRepo) UpdateProject(ctx context.Context, project *md.Project) error {
+	return t.updateProject(ctx, project.ID, map[string]interface{}{
+		"name":        project.Name,
+		"description": project.Description,
+	})
+}
+
+// ArchiveProject переносит проект в архив или возвращает из него
+func (t *TasksRepo) ArchiveProject(ctx context.Context, id int, archived bool) error {
+	return t.updateProject(ctx, id, map[string]interface{}{"archived": archived})
+}
+
+func (t *TasksRepo) updateProject(ctx context.Context, id int, columns map[string]interface{}) error {
+	result := t.db.WithContext(ctx).Model(&md.Project{}).Where("id = ?", id).Updates(columns)
+	if result.Error != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrUpdateProject, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrProjectNotFound
+	}
+	return nil
+}
+
+// DeleteProject удаляет проект, его задачи остаются без проекта (ON DELETE SET NULL)
+func (t *TasksRepo) DeleteProject(ctx context.Context, id int) error {
+	result := t.db.WithContext(ctx).Delete(&md.Project{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrDeleteProject, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrProjectNotFound
+	}
+	return nil
+}
+
+// TaskIDsByProject id задач проекта, нужны для обновления кэша
+func (t *TasksRepo) TaskIDsByProject(ctx context.Context, projectID int) ([]int, error) {
+	var ids []int
+	err := t.db.WithContext(ctx).Model(&md.Task{}).Where("project_id = ?", projectID).Pluck("id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetTasks, err)
+	}
+	return ids, nil
+}
+
+// ProjectStats считает задачи проекта относительно дня today (YYYYMMDD).
+// Отмененные задачи считаются отдельно и в остальные счетчики, кроме total, не входят.
+func (t *TasksRepo) ProjectStats(ctx context.Context, projectID int, today string) (*md.ProjectStats, error) {
+	stats := &md.ProjectStats{ProjectID: projectID}
+	err := t.db.WithContext(ctx).Raw(`SELECT
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE status <> ? AND date < ?) AS overdue,
+			COUNT(*) FILTER (WHERE status <> ? AND date = ?) AS due_today,
+			COUNT(*) FILTER (WHERE status <> ? AND date > ?) AS upcoming,
+			COUNT(*) FILTER (WHERE status <> ? AND priority >= ?) AS high_priority,
+			COUNT(*) FILTER (WHERE status <> ? AND repeat <> '') AS repeating,
+			COUNT(*) FILTER (WHERE status = ?) AS cancelled
+		FROM tasks WHERE project_id = ?`,
+		md.StatusCancelled, today, md.StatusCancelled, today, md.StatusCancelled, today,
+		md.StatusCancelled, md.PriorityHigh, md.StatusCancelled, md.StatusCancelled, projectID).Scan(stats).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetProjects, err)
+	}
+	return stats, nil
+}
@@ -0,0 +1,37 @@
+package repo
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	cm "github.com/Vasya-lis/firstWorkWithgRPC/common"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testRepo репозиторий на пустой тестовой базе. Запросы написаны под Postgres,
+// поэтому тест пропускается без TEST_DATABASE_DSN, например
+// TEST_DATABASE_DSN="host=localhost user=postgres password=postgres dbname=scheduler_test sslmode=disable"
+func testRepo(t testing.TB) *TasksRepo {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	if err := db.AutoMigrate(&md.Project{}, &md.Task{}, &md.MissedOccurrence{}, &md.Tag{}, &md.TaskTag{}, &md.TaskDependency{}, &md.StatusChange{}, &md.TaskRevision{}, &md.AuditEvent{}, &md.Attachment{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if err := db.Exec("TRUNCATE projects, tasks, tags, audit_events RESTART IDENTITY CASCADE").Error; err != nil {
+		t.Fatalf("truncate: %v", err)
+	}
+	clock := &cm.FrozenClock{}
+	clock.Freeze(time.Date(2024, 1, 26, 12, 0, 0, 0, time.UTC))
+	return NewTasksRepo(db, clock)
+}
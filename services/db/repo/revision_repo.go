@@ -0,0 +1,47 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+	"gorm.io/gorm/clause"
+)
+
+// AddRevision сохраняет снимок задачи следующим номером. Вызывается внутри InTx
+// под блокировкой строки задачи, поэтому номера не повторяются.
+func (t *TasksRepo) AddRevision(ctx context.Context, taskID int, snapshot md.TaskSnapshot) error {
+	db := t.db.WithContext(ctx)
+	var last int
+	err := db.Model(&md.TaskRevision{}).
+		Where("task_id = ?", taskID).
+		Select("COALESCE(MAX(number), 0)").
+		Scan(&last).Error
+	if err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrAddRevision, err)
+	}
+
+	revision := &md.TaskRevision{TaskID: taskID, Number: last + 1, Snapshot: snapshot}
+	if err := db.Omit(clause.Associations).Create(revision).Error; err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrAddRevision, err)
+	}
+	return nil
+}
+
+// Revisions версии задачи по возрастанию номера
+func (t *TasksRepo) Revisions(ctx context.Context, taskID int) ([]*md.TaskRevision, error) {
+	if taskID <= 0 {
+		return nil, apperrors.ErrInvalidTaskID
+	}
+
+	var revisions []*md.TaskRevision
+	err := t.db.WithContext(ctx).Omit(clause.Associations).
+		Where("task_id = ?", taskID).
+		Order("number ASC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetRevisions, err)
+	}
+	return revisions, nil
+}
@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+	"gorm.io/gorm/clause"
+)
+
+// SetStatus меняет статус задачи и записывает переход в историю, вызывается внутри InTx
+func (t *TasksRepo) SetStatus(ctx context.Context, id int, from, to md.Status, at time.Time) error {
+	if id <= 0 {
+		return apperrors.ErrInvalidTaskID
+	}
+
+	if err := t.updateColumns(ctx, id, apperrors.ErrSetStatus, map[string]interface{}{"status": to}); err != nil {
+		return err
+	}
+	return t.AddStatusChange(ctx, id, from, to, at)
+}
+
+// AddStatusChange записывает переход в историю, не меняя задачу. Переход из
+// StatusUnspecified — начальный статус созданной задачи.
+func (t *TasksRepo) AddStatusChange(ctx context.Context, id int, from, to md.Status, at time.Time) error {
+	change := &md.StatusChange{TaskID: id, From: from, To: to, ChangedAt: at}
+	if err := t.db.WithContext(ctx).Omit(clause.Associations).Create(change).Error; err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrSetStatus, err)
+	}
+	return nil
+}
+
+// StatusChanges история статусов задачи по времени
+func (t *TasksRepo) StatusChanges(ctx context.Context, taskID int) ([]*md.StatusChange, error) {
+	if taskID <= 0 {
+		return nil, apperrors.ErrInvalidTaskID
+	}
+
+	var changes []*md.StatusChange
+	err := t.db.WithContext(ctx).Omit(clause.Associations).
+		Where("task_id = ?", taskID).
+		Order("changed_at ASC, id ASC").
+		Find(&changes).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetStatusChanges, err)
+	}
+	return changes, nil
+}
@@ -0,0 +1,45 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// Descendants id всех подзадач задачи на любой глубине и высота поддерева (0 — подзадач нет)
+func (t *TasksRepo) Descendants(ctx context.Context, id int) ([]int, int, error) {
+	var rows []struct {
+		ID    int
+		Depth int
+	}
+	err := t.db.WithContext(ctx).Raw(`WITH RECURSIVE sub AS (
+			SELECT id, 1 AS depth FROM tasks WHERE parent_id = ?
+			UNION ALL
+			SELECT tasks.id, sub.depth + 1 FROM tasks JOIN sub ON tasks.parent_id = sub.id
+		)
+		SELECT id, depth FROM sub`, id).Scan(&rows).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w:%w", apperrors.ErrGetSubtasks, err)
+	}
+
+	ids := make([]int, 0, len(rows))
+	height := 0
+	for _, row := range rows {
+		ids = append(ids, row.ID)
+		height = max(height, row.Depth)
+	}
+	return ids, height, nil
+}
+
+// SetChecklist сохраняет чек-лист задачи
+func (t *TasksRepo) SetChecklist(ctx context.Context, id int, checklist md.Checklist) error {
+	if id <= 0 {
+		return apperrors.ErrInvalidTaskID
+	}
+
+	return t.updateColumns(ctx, id, apperrors.ErrUpdateChecklist, map[string]interface{}{
+		"checklist": checklist,
+	})
+}
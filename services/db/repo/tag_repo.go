@@ -0,0 +1,204 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// loadTags заполняет метки задач одним запросом по task_tags
+func loadTags(db *gorm.DB, tasks ...*md.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+	byID := make(map[int]*md.Task, len(tasks))
+	ids := make([]int, 0, len(tasks))
+	for _, task := range tasks {
+		task.Tags = nil
+		byID[task.ID] = task
+		ids = append(ids, task.ID)
+	}
+
+	var rows []struct {
+		TaskID int
+		Name   string
+	}
+	err := db.Table("task_tags").
+		Select("task_tags.task_id, tags.name").
+		Joins("JOIN tags ON tags.id = task_tags.tag_id").
+		Where("task_tags.task_id IN ?", ids).
+		Order("tags.name").
+		Scan(&rows).Error
+	if err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrGetTags, err)
+	}
+	for _, row := range rows {
+		byID[row.TaskID].Tags = append(byID[row.TaskID].Tags, row.Name)
+	}
+	return nil
+}
+
+// setTags заменяет метки задачи, недостающие метки создаются
+func setTags(db *gorm.DB, taskID int, names []string) error {
+	if err := db.Where("task_id = ?", taskID).Delete(&md.TaskTag{}).Error; err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrSetTaskTags, err)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	tags := make([]md.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, md.Tag{Name: name})
+	}
+	if err := db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(&tags).Error; err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrSetTaskTags, err)
+	}
+
+	var ids []int
+	if err := db.Model(&md.Tag{}).Where("name IN ?", names).Pluck("id", &ids).Error; err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrSetTaskTags, err)
+	}
+	links := make([]md.TaskTag, 0, len(ids))
+	for _, id := range ids {
+		links = append(links, md.TaskTag{TaskID: taskID, TagID: id})
+	}
+	if err := db.Omit(clause.Associations).Create(&links).Error; err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrSetTaskTags, err)
+	}
+	return nil
+}
+
+// Tags список меток с числом задач, по имени
+func (t *TasksRepo) Tags(ctx context.Context) ([]*md.Tag, error) {
+	var tags []*md.Tag
+	err := t.db.WithContext(ctx).Model(&md.Tag{}).
+		Select("tags.id, tags.name, COUNT(task_tags.task_id) AS task_count").
+		Joins("LEFT JOIN task_tags ON task_tags.tag_id = tags.id").
+		Group("tags.id").
+		Order("tags.name").
+		Find(&tags).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetTags, err)
+	}
+	if tags == nil {
+		tags = []*md.Tag{}
+	}
+	return tags, nil
+}
+
+// GetTag метка по id с числом задач
+func (t *TasksRepo) GetTag(ctx context.Context, id int) (*md.Tag, error) {
+	var tag md.Tag
+	err := t.db.WithContext(ctx).Model(&md.Tag{}).
+		Select("tags.id, tags.name, COUNT(task_tags.task_id) AS task_count").
+		Joins("LEFT JOIN task_tags ON task_tags.tag_id = tags.id").
+		Where("tags.id = ?", id).
+		Group("tags.id").
+		Take(&tag).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrTagNotFound
+		}
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetTags, err)
+	}
+	return &tag, nil
+}
+
+// AddTag создает метку, занятое имя — ErrTagExists
+func (t *TasksRepo) AddTag(ctx context.Context, name string) (*md.Tag, error) {
+	tag := &md.Tag{Name: name}
+	result := t.db.WithContext(ctx).Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "name"}}, DoNothing: true}).Create(tag)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrAddTag, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, apperrors.ErrTagExists.With("name", name)
+	}
+	return tag, nil
+}
+
+// TagIDByName id метки по имени, 0 — метки нет
+func (t *TasksRepo) TagIDByName(ctx context.Context, name string) (int, error) {
+	var ids []int
+	if err := t.db.WithContext(ctx).Model(&md.Tag{}).Where("name = ?", name).Pluck("id", &ids).Error; err != nil {
+		return 0, fmt.Errorf("%w:%w", apperrors.ErrGetTags, err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	return ids[0], nil
+}
+
+// RenameTag меняет имя метки
+func (t *TasksRepo) RenameTag(ctx context.Context, id int, name string) error {
+	result := t.db.WithContext(ctx).Model(&md.Tag{}).Where("id = ?", id).Update("name", name)
+	if result.Error != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrUpdateTag, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrTagNotFound
+	}
+	return nil
+}
+
+// DeleteTag удаляет метку, связи с задачами удаляются каскадно
+func (t *TasksRepo) DeleteTag(ctx context.Context, id int) error {
+	result := t.db.WithContext(ctx).Delete(&md.Tag{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrDeleteTag, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.ErrTagNotFound
+	}
+	return nil
+}
+
+// MergeTags переносит задачи меток sourceIDs на метку targetID и удаляет исходные метки
+func (t *TasksRepo) MergeTags(ctx context.Context, sourceIDs []int, targetID int) error {
+	db := t.db.WithContext(ctx)
+	err := db.Exec(`INSERT INTO task_tags (task_id, tag_id)
+		SELECT DISTINCT task_id, ? FROM task_tags WHERE tag_id IN ?
+		ON CONFLICT DO NOTHING`, targetID, sourceIDs).Error
+	if err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrMergeTags, err)
+	}
+	if err := db.Delete(&md.Tag{}, sourceIDs).Error; err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrMergeTags, err)
+	}
+	return nil
+}
+
+// TaskIDsByTags id задач с любой из меток, нужны для обновления кэша
+func (t *TasksRepo) TaskIDsByTags(ctx context.Context, tagIDs []int) ([]int, error) {
+	var ids []int
+	err := t.db.WithContext(ctx).Model(&md.TaskTag{}).
+		Distinct("task_id").
+		Where("tag_id IN ?", tagIDs).
+		Pluck("task_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetTags, err)
+	}
+	return ids, nil
+}
+
+// TasksByIDs задачи с метками и зависимостями по списку id
+func (t *TasksRepo) TasksByIDs(ctx context.Context, ids []int) ([]*md.Task, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	db := t.db.WithContext(ctx)
+	var tasks []*md.Task
+	if err := db.Where("id IN ?", ids).Find(&tasks).Error; err != nil {
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetTasks, err)
+	}
+	if err := loadRelations(db, tasks...); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
@@ -1,61 +1,70 @@
 package repo
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
-	"sync"
 	"time"
 
+	cm "github.com/Vasya-lis/firstWorkWithgRPC/common"
 	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
 	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// TasksRepo работает с задачами в Postgres. Согласованность обеспечивает база:
+// изменения из нескольких шагов выполняются в InTx, строка блокируется GetTaskForUpdate.
 type TasksRepo struct {
-	db *gorm.DB
-	mu sync.RWMutex
+	db    *gorm.DB
+	clock cm.Clock
 }
 
-func NewTasksRepo(db *gorm.DB) *TasksRepo {
+func NewTasksRepo(db *gorm.DB, clock cm.Clock) *TasksRepo {
 	return &TasksRepo{
-		db: db,
+		db:    db,
+		clock: clock,
 	}
 }
 
-func (t *TasksRepo) AddTask(task *md.Task) (int, error) {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-
+func (t *TasksRepo) AddTask(ctx context.Context, task *md.Task) (int, error) {
 	if task == nil {
 		return 0, fmt.Errorf("%w:task is nil", apperrors.ErrTaskNotFound)
 	}
 
 	if task.Date == "" {
-		task.Date = time.Now().Format("20060102")
+		now, err := cm.NowIn(t.clock.Now(), task.TZ)
+		if err != nil {
+			return 0, fmt.Errorf("%w:%w", apperrors.ErrAddTask, err)
+		}
+		task.Date = now.Format(cm.FormDate)
 	}
 
 	if task.Title == "" {
 		return 0, apperrors.ErrTitleRequired
 	}
 
-	result := t.db.Create(task)
-	if result.Error != nil {
-		return 0, fmt.Errorf("%w:%w", apperrors.ErrAddTask, result.Error)
+	// задача и ее метки сохраняются вместе
+	err := t.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		if err := db.Create(task).Error; err != nil {
+			return fmt.Errorf("%w:%w", apperrors.ErrAddTask, err)
+		}
+		return setTags(db, task.ID, task.Tags)
+	})
+	if err != nil {
+		return 0, err
 	}
 
 	return task.ID, nil
 }
 
-// список задач с поиском и лимитом подлежит репозиторию тасков
-func (t *TasksRepo) Tasks(limit int, search string) ([]*md.Task, error) {
-	t.mu.RLock()
-	defer t.mu.RUnlock()
-
+// список задач с поиском, фильтром по приоритету и лимитом подлежит репозиторию тасков
+func (t *TasksRepo) Tasks(ctx context.Context, filter md.TaskFilter) ([]*md.Task, error) {
 	var tasks []*md.Task
-	query := t.db.Session(&gorm.Session{}).Model(&md.Task{})
+	query := t.db.WithContext(ctx).Model(&md.Task{})
 
-	search = strings.TrimSpace(search)
+	search := strings.TrimSpace(filter.Search)
 
 	switch {
 	case search == "":
@@ -71,13 +80,41 @@ func (t *TasksRepo) Tasks(limit int, search string) ([]*md.Task, error) {
 		query = query.Where("title LIKE ? OR comment LIKE ?", like, like)
 	}
 
-	if limit > 0 {
-		query = query.Limit(limit)
+	if filter.MinPriority != md.PriorityUnspecified {
+		query = query.Where("priority >= ?", filter.MinPriority)
+	}
+
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+
+	if filter.ProjectID != 0 {
+		query = query.Where("project_id = ?", filter.ProjectID)
+	}
+
+	if filter.ParentID != 0 {
+		query = query.Where("parent_id = ?", filter.ParentID)
+	}
+
+	// задачи, у которых есть все метки фильтра
+	if len(filter.Tags) > 0 {
+		query = query.Where(`id IN (SELECT task_tags.task_id FROM task_tags
+			JOIN tags ON tags.id = task_tags.tag_id
+			WHERE tags.name IN ?
+			GROUP BY task_tags.task_id
+			HAVING COUNT(DISTINCT tags.id) = ?)`, filter.Tags, len(filter.Tags))
 	}
 
-	if err := query.Order("date ASC").Find(&tasks).Error; err != nil {
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if err := query.Order(filter.OrderSQL()).Find(&tasks).Error; err != nil {
 		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetTasks, err)
 	}
+	if err := loadRelations(t.db.WithContext(ctx), tasks...); err != nil {
+		return nil, err
+	}
 
 	if tasks == nil {
 		tasks = []*md.Task{}
@@ -99,50 +136,67 @@ func parseSearchDate(s string) (string, error) {
 	return t.Format("20060102"), nil
 }
 
+// InTx выполняет fn в транзакции, репозиторий tx работает внутри нее.
+// Ошибка fn откатывает транзакцию.
+func (t *TasksRepo) InTx(ctx context.Context, fn func(tx *TasksRepo) error) error {
+	return t.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		return fn(&TasksRepo{db: db, clock: t.clock})
+	})
+}
+
 // одна задача по id
-func (t *TasksRepo) GetTask(id int) (*md.Task, error) {
-	t.mu.RLock()
-	defer t.mu.RUnlock()
+func (t *TasksRepo) GetTask(ctx context.Context, id int) (*md.Task, error) {
+	return t.getTask(t.db.WithContext(ctx), id)
+}
+
+// GetTaskForUpdate читает задачу и блокирует строку до конца транзакции (SELECT ... FOR UPDATE),
+// вызывается внутри InTx
+func (t *TasksRepo) GetTaskForUpdate(ctx context.Context, id int) (*md.Task, error) {
+	return t.getTask(t.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), id)
+}
 
+func (t *TasksRepo) getTask(db *gorm.DB, id int) (*md.Task, error) {
 	var task md.Task
-	result := t.db.First(&task, id)
+	result := db.First(&task, id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, apperrors.ErrTaskNotFound
 		}
 		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetTask, result.Error)
 	}
+	// условия и блокировка запроса задачи к меткам не относятся
+	if err := loadRelations(db.Session(&gorm.Session{NewDB: true}), &task); err != nil {
+		return nil, err
+	}
 	return &task, nil
 }
-func (t *TasksRepo) Updates(task *md.Task) error {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-
+func (t *TasksRepo) Updates(ctx context.Context, task *md.Task) error {
 	if task.ID == 0 {
 		return apperrors.ErrInvalidTaskID
 	}
 
-	result := t.db.Save(task)
-	if result.Error != nil {
-		return fmt.Errorf("%w:%w", apperrors.ErrUpdateTask, result.Error)
-	}
+	// статус меняется только через SetStatus, чтобы каждый переход попал в историю,
+	// дата создания не меняется никогда
+	return t.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		result := db.Omit("status", "created_at").Save(task)
+		if result.Error != nil {
+			return fmt.Errorf("%w:%w", apperrors.ErrUpdateTask, result.Error)
+		}
 
-	if result.RowsAffected == 0 {
-		return apperrors.ErrTaskNotFound
-	}
+		if result.RowsAffected == 0 {
+			return apperrors.ErrTaskNotFound
+		}
 
-	return nil
+		return setTags(db, task.ID, task.Tags)
+	})
 }
 
-func (t *TasksRepo) DeleteTask(id int) error {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-
+func (t *TasksRepo) DeleteTask(ctx context.Context, id int) error {
 	if id <= 0 {
 		return apperrors.ErrInvalidTaskID
 	}
 
-	result := t.db.Delete(&md.Task{}, id)
+	result := t.db.WithContext(ctx).Delete(&md.Task{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("%w:%w", apperrors.ErrDeleteTask, result.Error)
 	}
@@ -154,10 +208,9 @@ func (t *TasksRepo) DeleteTask(id int) error {
 	return nil
 }
 
-func (t *TasksRepo) UpdateDate(next string, id int) error {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-
+// UpdateDate переносит задачу на следующую дату, сохраняет остаток повторений
+// и сбрасывает отложенное повторение
+func (t *TasksRepo) UpdateDate(ctx context.Context, next string, remaining int, id int) error {
 	if id <= 0 {
 		return apperrors.ErrInvalidTaskID
 	}
@@ -165,9 +218,44 @@ func (t *TasksRepo) UpdateDate(next string, id int) error {
 		return apperrors.ErrDateRequired
 	}
 
-	result := t.db.Model(&md.Task{}).Where("id = ?", id).Update("date", next)
+	return t.updateColumns(ctx, id, apperrors.ErrUpdateTaskDate, map[string]interface{}{
+		"date":      next,
+		"remaining": remaining,
+		"anchor":    "",
+	})
+}
+
+// Snooze переносит текущее повторение на дату date, anchor хранит дату по расписанию
+func (t *TasksRepo) Snooze(ctx context.Context, id int, date, anchor string) error {
+	if id <= 0 {
+		return apperrors.ErrInvalidTaskID
+	}
+	if date == "" {
+		return apperrors.ErrDateRequired
+	}
+
+	return t.updateColumns(ctx, id, apperrors.ErrSnoozeTask, map[string]interface{}{
+		"date":   date,
+		"anchor": anchor,
+	})
+}
+
+// SetExceptions сохраняет даты-исключения серии
+func (t *TasksRepo) SetExceptions(ctx context.Context, id int, exceptions md.DateList) error {
+	if id <= 0 {
+		return apperrors.ErrInvalidTaskID
+	}
+
+	return t.updateColumns(ctx, id, apperrors.ErrSkipOccurrence, map[string]interface{}{
+		"exceptions": exceptions,
+	})
+}
+
+// updateColumns обновляет поля задачи одним запросом
+func (t *TasksRepo) updateColumns(ctx context.Context, id int, errUpdate error, columns map[string]interface{}) error {
+	result := t.db.WithContext(ctx).Model(&md.Task{}).Where("id = ?", id).Updates(columns)
 	if result.Error != nil {
-		return fmt.Errorf("%w:%w", apperrors.ErrUpdateTaskDate, result.Error)
+		return fmt.Errorf("%w:%w", errUpdate, result.Error)
 	}
 
 	if result.RowsAffected == 0 {
@@ -176,3 +264,28 @@ func (t *TasksRepo) UpdateDate(next string, id int) error {
 
 	return nil
 }
+
+// AddMissed записывает пропущенные повторения в историю
+func (t *TasksRepo) AddMissed(ctx context.Context, missed []*md.MissedOccurrence) error {
+	if len(missed) == 0 {
+		return nil
+	}
+
+	if err := t.db.WithContext(ctx).Create(&missed).Error; err != nil {
+		return fmt.Errorf("%w:%w", apperrors.ErrAddMissed, err)
+	}
+	return nil
+}
+
+// Missed история пропущенных повторений задачи
+func (t *TasksRepo) Missed(ctx context.Context, taskID int) ([]*md.MissedOccurrence, error) {
+	if taskID <= 0 {
+		return nil, apperrors.ErrInvalidTaskID
+	}
+
+	var missed []*md.MissedOccurrence
+	if err := t.db.WithContext(ctx).Where("task_id = ?", taskID).Order("date ASC").Find(&missed).Error; err != nil {
+		return nil, fmt.Errorf("%w:%w", apperrors.ErrGetMissed, err)
+	}
+	return missed, nil
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+)
+
+// ListRevisions возвращает прежние версии задачи с отличиями
+func (s *TaskServer) ListRevisions(ctx context.Context, req *pb.IDRequest) (*pb.ListRevisionsResponse, error) {
+	revisions, err := s.ts.ListRevisions(ctx, int(req.Id))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListRevisionsResponse{}
+	for _, r := range revisions {
+		resp.Revisions = append(resp.Revisions, revisionToProto(r))
+	}
+	return resp, nil
+}
+
+// GetRevision возвращает версию задачи по номеру
+func (s *TaskServer) GetRevision(ctx context.Context, req *pb.RevisionRequest) (*pb.TaskRevision, error) {
+	revision, err := s.ts.GetRevision(ctx, int(req.TaskId), int(req.Number))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return revisionToProto(revision), nil
+}
+
+// RevertTask возвращает задаче поля версии
+func (s *TaskServer) RevertTask(ctx context.Context, req *pb.RevisionRequest) (*pb.Task, error) {
+	task, err := s.ts.RevertTask(ctx, int(req.TaskId), int(req.Number))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return taskToProto(task), nil
+}
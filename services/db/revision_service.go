@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/services/db/repo"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// ListRevisions возвращает прежние версии задачи. У каждой версии есть отличия
+// от следующей за ней, у последней — от текущей задачи.
+func (s *TasksService) ListRevisions(ctx context.Context, id int) ([]*md.TaskRevision, error) {
+	var task *md.Task
+	var revisions []*md.TaskRevision
+	// задача и версии читаются из одной транзакции, иначе между ними может вклиниться изменение
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		var err error
+		if task, err = tx.GetTask(ctx, id); err != nil {
+			return err
+		}
+		revisions, err = tx.Revisions(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, revision := range revisions {
+		next := task.Snapshot()
+		if i+1 < len(revisions) {
+			next = revisions[i+1].Snapshot
+		}
+		if revision.Changes, err = md.Diff(revision.Snapshot, next); err != nil {
+			return nil, err
+		}
+	}
+	return revisions, nil
+}
+
+// GetRevision возвращает версию задачи с номером number и ее отличия от следующей
+func (s *TasksService) GetRevision(ctx context.Context, id, number int) (*md.TaskRevision, error) {
+	revisions, err := s.ListRevisions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, revision := range revisions {
+		if revision.Number == number {
+			return revision, nil
+		}
+	}
+	return nil, apperrors.ErrRevisionNotFound
+}
+
+// RevertTask возвращает задаче поля версии number. Это обычное изменение: текущая
+// версия сохраняется, задача проверяется и нормализуется как в UpdateTask.
+// Состояние серии не откатывается: счетчик remaining сохраняется, пока версия не меняет
+// правило, отсрочка (anchor) и исключения остаются текущими — откат не продлевает
+// серию и не возвращает уже использованную отсрочку.
+func (s *TasksService) RevertTask(ctx context.Context, id, number int) (*md.Task, error) {
+	revision, err := s.GetRevision(ctx, id, number)
+	if err != nil {
+		return nil, err
+	}
+	return s.updateTask(ctx, id, md.AuditRevert, func(current *md.Task) (*md.Task, bool) {
+		task := current.Clone()
+		revision.Snapshot.Apply(task)
+		task.Anchor = current.Anchor
+		task.Exceptions = current.Exceptions
+		if task.Repeat == current.Repeat {
+			task.Remaining = current.Remaining
+		} else {
+			// новое правило — счетчик начинается с полного count
+			task.Remaining = 0
+		}
+		// как в UpdateTask: дата переносится, только если версия меняет дату или правило
+		return task, task.Date != current.Date || task.Repeat != current.Repeat
+	})
+}
+
+// saveRevision сохраняет задачу до изменения, вызывается под блокировкой строки
+func saveRevision(ctx context.Context, tx *repo.TasksRepo, task *md.Task) error {
+	return tx.AddRevision(ctx, task.ID, task.Snapshot())
+}
+
+// refreshTask перечитывает измененную задачу в той же транзакции: updated_at
+// выставляет база, а указатель на задачу уже может лежать в изменениях кэша
+func refreshTask(ctx context.Context, tx *repo.TasksRepo, task *md.Task) error {
+	fresh, err := tx.GetTask(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	*task = *fresh
+	return nil
+}
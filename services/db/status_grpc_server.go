@@ -0,0 +1,38 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// SetStatus меняет статус задачи по допустимому переходу
+func (s *TaskServer) SetStatus(ctx context.Context, req *pb.SetStatusRequest) (*pb.Task, error) {
+	task, err := s.ts.SetStatus(ctx, int(req.Id), md.Status(req.Status))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return taskToProto(task), nil
+}
+
+// ListStatusChanges возвращает историю статусов задачи
+func (s *TaskServer) ListStatusChanges(ctx context.Context, req *pb.IDRequest) (*pb.ListStatusChangesResponse, error) {
+	changes, err := s.ts.ListStatusChanges(ctx, int(req.Id))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListStatusChangesResponse{}
+	for _, c := range changes {
+		resp.Changes = append(resp.Changes, &pb.StatusChange{
+			Id:        int32(c.ID),
+			TaskId:    int32(c.TaskID),
+			From:      pb.Status(c.From),
+			To:        pb.Status(c.To),
+			ChangedAt: c.ChangedAt.Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
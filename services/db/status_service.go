@@ -0,0 +1,74 @@
+package db
+
+import (
+	"context"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/services/db/repo"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// SetStatus переводит задачу в статус status, если переход допустим, и возвращает ее.
+// Тот же статус ничего не меняет и в историю не попадает.
+func (s *TasksService) SetStatus(ctx context.Context, id int, status md.Status) (*md.Task, error) {
+	if !status.Valid() {
+		return nil, apperrors.NewFieldError("status", apperrors.ErrInvalidStatus)
+	}
+
+	var task *md.Task
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		var err error
+		task, err = tx.GetTaskForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if task.Status == status {
+			return nil
+		}
+		if !task.Status.CanTransition(status) {
+			return apperrors.ErrStatusTransition.With("from", task.Status.String()).With("to", status.String())
+		}
+		before := task.Clone()
+		if err := s.changeStatus(ctx, tx, task, status); err != nil {
+			return err
+		}
+		if err := refreshTask(ctx, tx, task); err != nil {
+			return err
+		}
+		return audit(ctx, tx, md.AuditSetStatus, id, before, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.applyCache(ctx, &cacheUpdate{set: []*md.Task{task}})
+	if err := s.markBlocked(ctx, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// ListStatusChanges история статусов задачи
+func (s *TasksService) ListStatusChanges(ctx context.Context, id int) ([]*md.StatusChange, error) {
+	if _, err := s.tr.GetTask(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.tr.StatusChanges(ctx, id)
+}
+
+// changeStatus меняет статус задачи в транзакции tx, время перехода берется из часов сервиса
+func (s *TasksService) changeStatus(ctx context.Context, tx *repo.TasksRepo, task *md.Task, status md.Status) error {
+	if err := tx.SetStatus(ctx, task.ID, task.Status, status, s.clock.Now()); err != nil {
+		return err
+	}
+	task.Status = status
+	return nil
+}
+
+// checkDone отклоняет выполнение отмененной задачи: ее сначала возвращают в todo
+func checkDone(task *md.Task) error {
+	if task.Status == md.StatusCancelled {
+		return apperrors.ErrStatusTransition.With("from", task.Status.String()).With("to", "done")
+	}
+	return nil
+}
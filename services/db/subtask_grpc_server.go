@@ -0,0 +1,30 @@
+package db
+
+import (
+	"context"
+
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+)
+
+// ListSubtasks возвращает прямые подзадачи задачи
+func (s *TaskServer) ListSubtasks(ctx context.Context, req *pb.IDRequest) (*pb.ListTasksResponse, error) {
+	tasks, err := s.ts.ListSubtasks(ctx, int(req.Id))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListTasksResponse{}
+	for _, t := range tasks {
+		resp.Tasks = append(resp.Tasks, taskToProto(t))
+	}
+	return resp, nil
+}
+
+// CheckItem отмечает пункт чек-листа задачи
+func (s *TaskServer) CheckItem(ctx context.Context, req *pb.CheckItemRequest) (*pb.Task, error) {
+	task, err := s.ts.CheckItem(ctx, int(req.TaskId), int(req.Index), req.Done)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return taskToProto(task), nil
+}
@@ -0,0 +1,104 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/services/db/repo"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// maxSubtaskDepth сколько уровней подзадач может быть под задачей верхнего уровня
+const maxSubtaskDepth = 3
+
+// ListSubtasks возвращает прямые подзадачи задачи
+func (s *TasksService) ListSubtasks(ctx context.Context, id int) ([]*md.Task, error) {
+	if _, err := s.GetTask(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.GetTasks(ctx, md.TaskFilter{ParentID: id})
+}
+
+// checkParent проверяет в транзакции tx родителя задачи: он существует, задача не становится
+// подзадачей самой себя или своих подзадач, глубина не превышает maxSubtaskDepth.
+// Родитель и его предки блокируются: параллельный перенос в ту же цепочку ждет фиксации
+// и видит уже новое дерево, поэтому вместе два переноса не создадут цикл или лишний уровень.
+func checkParent(ctx context.Context, tx *repo.TasksRepo, task *md.Task) error {
+	if task.ParentID == nil {
+		return nil
+	}
+	parentID := *task.ParentID
+
+	// цепочка от родителя вверх: задача в ней — значит, родитель среди ее подзадач
+	depth := -1
+	for id := parentID; ; {
+		if id == task.ID {
+			return apperrors.NewFieldError("parent_id", apperrors.ErrSubtaskCycle)
+		}
+		ancestor, err := tx.GetTaskForUpdate(ctx, id)
+		if err != nil {
+			if depth < 0 && errors.Is(err, apperrors.ErrTaskNotFound) {
+				return apperrors.NewFieldError("parent_id", apperrors.ErrParentNotFound)
+			}
+			return err
+		}
+		depth++
+		if ancestor.ParentID == nil {
+			break
+		}
+		// глубже maxSubtaskDepth задача все равно не поместится
+		if depth >= maxSubtaskDepth {
+			return apperrors.NewFieldError("parent_id", apperrors.ErrSubtaskTooDeep.With("max", strconv.Itoa(maxSubtaskDepth)))
+		}
+		id = *ancestor.ParentID
+	}
+
+	// у новой задачи поддерева нет, у существующей переносится все поддерево
+	height := 0
+	if task.ID > 0 {
+		_, h, err := tx.Descendants(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+		height = h
+	}
+	if depth+1+height > maxSubtaskDepth {
+		return apperrors.NewFieldError("parent_id", apperrors.ErrSubtaskTooDeep.With("max", strconv.Itoa(maxSubtaskDepth)))
+	}
+	return nil
+}
+
+// CheckItem отмечает пункт чек-листа или снимает отметку, возвращает задачу
+func (s *TasksService) CheckItem(ctx context.Context, id, index int, done bool) (*md.Task, error) {
+	var task *md.Task
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		var err error
+		task, err = tx.GetTaskForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if index < 0 || index >= len(task.Checklist) {
+			return apperrors.ErrChecklistItem
+		}
+		if err := saveRevision(ctx, tx, task); err != nil {
+			return err
+		}
+		before := task.Clone()
+		task.Checklist[index].Done = done
+		if err := tx.SetChecklist(ctx, id, task.Checklist); err != nil {
+			return err
+		}
+		if err := refreshTask(ctx, tx, task); err != nil {
+			return err
+		}
+		return audit(ctx, tx, md.AuditCheckItem, id, before, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.applyCache(ctx, &cacheUpdate{set: []*md.Task{task}})
+	return task, nil
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+)
+
+// ListTags возвращает метки с числом задач
+func (s *TaskServer) ListTags(ctx context.Context, req *pb.EmptyRequest) (*pb.ListTagsResponse, error) {
+	tags, err := s.ts.ListTags(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListTagsResponse{}
+	for _, t := range tags {
+		resp.Tags = append(resp.Tags, tagToProto(t))
+	}
+	return resp, nil
+}
+
+// CreateTag создает метку
+func (s *TaskServer) CreateTag(ctx context.Context, req *pb.CreateTagRequest) (*pb.Tag, error) {
+	tag, err := s.ts.CreateTag(ctx, req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return tagToProto(tag), nil
+}
+
+// RenameTag переименовывает метку
+func (s *TaskServer) RenameTag(ctx context.Context, req *pb.RenameTagRequest) (*pb.Tag, error) {
+	tag, err := s.ts.RenameTag(ctx, int(req.Id), req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return tagToProto(tag), nil
+}
+
+// DeleteTag удаляет метку и снимает ее с задач
+func (s *TaskServer) DeleteTag(ctx context.Context, req *pb.IDRequest) (*pb.EmptyResponse, error) {
+	if err := s.ts.DeleteTag(ctx, int(req.Id)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.EmptyResponse{}, nil
+}
+
+// MergeTags объединяет метки
+func (s *TaskServer) MergeTags(ctx context.Context, req *pb.MergeTagsRequest) (*pb.Tag, error) {
+	tag, err := s.ts.MergeTags(ctx, idsFromProto(req.SourceIds), int(req.TargetId))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return tagToProto(tag), nil
+}
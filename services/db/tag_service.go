@@ -0,0 +1,156 @@
+package db
+
+import (
+	"context"
+	"slices"
+
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/services/db/repo"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// ListTags возвращает метки с числом задач
+func (s *TasksService) ListTags(ctx context.Context) ([]*md.Tag, error) {
+	return s.tr.Tags(ctx)
+}
+
+// CreateTag создает метку без задач
+func (s *TasksService) CreateTag(ctx context.Context, name string) (*md.Tag, error) {
+	return s.tr.AddTag(ctx, tagName(name))
+}
+
+// RenameTag переименовывает метку. Занятое имя — ошибка TagExists,
+// такие метки объединяются через MergeTags.
+func (s *TasksService) RenameTag(ctx context.Context, id int, name string) (*md.Tag, error) {
+	name = tagName(name)
+	changes := &cacheUpdate{}
+	var tag *md.Tag
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		var err error
+		tag, err = tx.GetTag(ctx, id)
+		if err != nil || tag.Name == name {
+			return err
+		}
+		other, err := tx.TagIDByName(ctx, name)
+		if err != nil {
+			return err
+		}
+		if other != 0 {
+			return apperrors.ErrTagExists.With("name", name)
+		}
+
+		taskIDs, err := tx.TaskIDsByTags(ctx, []int{id})
+		if err != nil {
+			return err
+		}
+		before, err := tx.TasksByIDs(ctx, taskIDs)
+		if err != nil {
+			return err
+		}
+		if err := tx.RenameTag(ctx, id, name); err != nil {
+			return err
+		}
+		tag.Name = name
+		after, err := reloadTasks(ctx, tx, taskIDs, changes)
+		if err != nil {
+			return err
+		}
+		return auditTasks(ctx, tx, md.AuditRenameTag, before, after)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.applyCache(ctx, changes)
+	return tag, nil
+}
+
+// DeleteTag удаляет метку и снимает ее с задач
+func (s *TasksService) DeleteTag(ctx context.Context, id int) error {
+	changes := &cacheUpdate{}
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		taskIDs, err := tx.TaskIDsByTags(ctx, []int{id})
+		if err != nil {
+			return err
+		}
+		before, err := tx.TasksByIDs(ctx, taskIDs)
+		if err != nil {
+			return err
+		}
+		if err := tx.DeleteTag(ctx, id); err != nil {
+			return err
+		}
+		after, err := reloadTasks(ctx, tx, taskIDs, changes)
+		if err != nil {
+			return err
+		}
+		return auditTasks(ctx, tx, md.AuditDeleteTag, before, after)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.applyCache(ctx, changes)
+	return nil
+}
+
+// MergeTags переносит задачи меток sourceIDs на метку targetID и удаляет исходные метки,
+// возвращает итоговую метку
+func (s *TasksService) MergeTags(ctx context.Context, sourceIDs []int, targetID int) (*md.Tag, error) {
+	// цель среди исходных меток не удаляем
+	sources := slices.DeleteFunc(slices.Compact(slices.Sorted(slices.Values(sourceIDs))), func(id int) bool {
+		return id == targetID
+	})
+	if len(sources) == 0 {
+		return nil, apperrors.NewFieldError("source_ids", apperrors.ErrFieldRequired)
+	}
+
+	changes := &cacheUpdate{}
+	var tag *md.Tag
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		for _, id := range append(sources, targetID) {
+			if _, err := tx.GetTag(ctx, id); err != nil {
+				return err
+			}
+		}
+
+		taskIDs, err := tx.TaskIDsByTags(ctx, sources)
+		if err != nil {
+			return err
+		}
+		before, err := tx.TasksByIDs(ctx, taskIDs)
+		if err != nil {
+			return err
+		}
+		if err := tx.MergeTags(ctx, sources, targetID); err != nil {
+			return err
+		}
+		after, err := reloadTasks(ctx, tx, taskIDs, changes)
+		if err != nil {
+			return err
+		}
+		if err := auditTasks(ctx, tx, md.AuditMergeTags, before, after); err != nil {
+			return err
+		}
+
+		tag, err = tx.GetTag(ctx, targetID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.applyCache(ctx, changes)
+	return tag, nil
+}
+
+// reloadTasks перечитывает задачи с измененными метками, чтобы обновить их в кэше после фиксации,
+// и возвращает их для журнала
+func reloadTasks(ctx context.Context, tx *repo.TasksRepo, ids []int, changes *cacheUpdate) ([]*md.Task, error) {
+	tasks, err := tx.TasksByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	changes.set = append(changes.set, tasks...)
+	return tasks, nil
+}
@@ -2,51 +2,55 @@ package db
 
 import (
 	"context"
-	"errors"
-	"log"
+	"fmt"
 	"time"
 
 	cm "github.com/Vasya-lis/firstWorkWithgRPC/common"
 	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
 	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
-	"github.com/Vasya-lis/firstWorkWithgRPC/services/models"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
 )
 
 type TaskServer struct {
 	pb.UnimplementedSchedulerServiceServer
-	ts *TasksService
+	ts         *TasksService
+	clock      *cm.FrozenClock
+	adminToken string // пустой — админские методы отключены
 }
 
-func NewTasksServer(ts *TasksService) *TaskServer {
+func NewTasksServer(ts *TasksService, clock *cm.FrozenClock, adminToken string) *TaskServer {
 	return &TaskServer{
-		ts: ts,
+		ts:         ts,
+		clock:      clock,
+		adminToken: adminToken,
 	}
 }
 
-// ListTasks возвращает список задач с лимитом и поиском
+// ListTasks возвращает список задач с лимитом, поиском, фильтрами по приоритету, статусу, меткам и проекту
 func (s *TaskServer) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
 
-	tasks, err := s.ts.GetTasks(ctx, int(req.Limit), req.Search)
+	var statuses []md.Status
+	for _, st := range req.Statuses {
+		statuses = append(statuses, md.Status(st))
+	}
+
+	tasks, err := s.ts.GetTasks(ctx, md.TaskFilter{
+		Limit:       int(req.Limit),
+		Search:      req.Search,
+		MinPriority: md.Priority(req.MinPriority),
+		Statuses:    statuses,
+		Order:       req.Order,
+		Tags:        req.Tags,
+		ProjectID:   int(req.ProjectId),
+	})
 	if err != nil {
-		if errors.Is(err, apperrors.ErrTaskNotFound) {
-			return nil, status.Errorf(codes.NotFound, "tasks with limit=%d and search= %s not found", req.Limit, req.Search)
-		}
-		return nil, status.Errorf(codes.Internal, "failed to get tasks: %v", err)
+		return nil, toStatus(err)
 	}
 
 	// конвертируем в прото буф
 	var pbTasks []*pb.Task
 	for _, t := range tasks {
-		pbTasks = append(pbTasks, &pb.Task{
-			Id:      int32(t.ID),
-			Date:    t.Date,
-			Title:   t.Title,
-			Comment: t.Comment,
-			Repeat:  t.Repeat,
-		})
-
+		pbTasks = append(pbTasks, taskToProto(t))
 	}
 
 	return &pb.ListTasksResponse{
@@ -61,47 +65,23 @@ func (s *TaskServer) GetTask(ctx context.Context, req *pb.IDRequest) (*pb.GetTas
 
 	task, err := s.ts.GetTask(ctx, int(req.Id))
 	if err != nil {
-		switch {
-		case errors.Is(err, apperrors.ErrTaskNotFound):
-			return nil, status.Errorf(codes.NotFound, "task id=%d not found", req.Id)
-		case errors.Is(err, apperrors.ErrInvalidTaskID):
-			return nil, status.Errorf(codes.InvalidArgument, "invalid task id=%d", req.Id)
-		default:
-			log.Printf("%v:%v", apperrors.ErrGetTask, err)
-			return nil, status.Error(codes.Internal, "internal server error")
-		}
+		return nil, toStatus(err)
 	}
 
 	return &pb.GetTaskResponse{
-		Task: &pb.Task{
-			Id:      int32(task.ID),
-			Date:    task.Date,
-			Title:   task.Title,
-			Comment: task.Comment,
-			Repeat:  task.Repeat,
-		},
+		Task: taskToProto(task),
 	}, nil
 }
 
 // AddTask добавляет новую задачу
 func (s *TaskServer) AddTask(ctx context.Context, req *pb.Task) (*pb.AddTaskResponse, error) {
 
-	task := &models.Task{
-		Date:    req.Date,
-		Title:   req.Title,
-		Comment: req.Comment,
-		Repeat:  req.Repeat,
-	}
+	task := taskFromProto(req)
+	task.ID = 0
 
 	id, err := s.ts.AddTask(ctx, task)
 	if err != nil {
-		switch {
-		case errors.Is(err, apperrors.ErrTitleRequired):
-			return nil, status.Error(codes.InvalidArgument, "title is required")
-		default:
-			log.Printf("AddTask error: %v", err)
-			return nil, status.Error(codes.Internal, "failed to add task")
-		}
+		return nil, toStatus(err)
 	}
 
 	return &pb.AddTaskResponse{Id: int32(id)}, nil
@@ -110,25 +90,14 @@ func (s *TaskServer) AddTask(ctx context.Context, req *pb.Task) (*pb.AddTaskResp
 // UpdateTask обновляет задачу
 func (s *TaskServer) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest) (*pb.EmptyResponse, error) {
 
-	task := &models.Task{
-		ID:      int(req.Task.Id),
-		Date:    req.Task.Date,
-		Title:   req.Task.Title,
-		Comment: req.Task.Comment,
-		Repeat:  req.Task.Repeat,
+	if req.Task == nil {
+		return nil, toStatus(apperrors.ErrTaskRequired)
 	}
+	task := taskFromProto(req.Task)
 
-	err := s.ts.UpdateTask(ctx, task)
+	err := s.ts.UpdateTask(ctx, task, req.Fields)
 	if err != nil {
-		switch {
-		case errors.Is(err, apperrors.ErrInvalidTaskID):
-			return nil, status.Error(codes.InvalidArgument, "invalid task id")
-		case errors.Is(err, apperrors.ErrTaskNotFound):
-			return nil, status.Error(codes.NotFound, "task not found")
-		default:
-			log.Printf("UpdateTask error: %v", err)
-			return nil, status.Error(codes.Internal, "failed to update task")
-		}
+		return nil, toStatus(err)
 	}
 
 	return &pb.EmptyResponse{}, nil
@@ -139,58 +108,97 @@ func (s *TaskServer) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest)
 func (s *TaskServer) DeleteTask(ctx context.Context, req *pb.IDRequest) (*pb.EmptyResponse, error) {
 
 	if err := s.ts.DeleteTask(ctx, int(req.Id)); err != nil {
-		switch {
-		case errors.Is(err, apperrors.ErrInvalidTaskID):
-			return nil, status.Errorf(codes.InvalidArgument, "invalid task id=%d", req.Id)
-		case errors.Is(err, apperrors.ErrTaskNotFound):
-			return nil, status.Errorf(codes.NotFound, "task id=%d not found", req.Id)
-		default:
-			log.Printf("DeleteTask error: %v", err)
-			return nil, status.Errorf(codes.Internal, "failed to delete task id=%d", req.Id)
-		}
+		return nil, toStatus(err)
 	}
 
 	return &pb.EmptyResponse{}, nil
 }
 
-// DoneTask отмечает задачу как выполненную и удаляет
-func (s *TaskServer) DoneTask(ctx context.Context, req *pb.IDRequest) (*pb.EmptyResponse, error) {
-
-	if err := s.ts.DeleteTask(ctx, int(req.Id)); err != nil {
-		log.Printf("%v: %v", apperrors.ErrDeleteTask, err)
-		return nil, status.Errorf(codes.Internal, "failed to mark task as done")
+// DoneTask отмечает задачу как выполненную: переносит повторяющуюся или удаляет завершенную.
+// Задачу с невыполненными зависимостями выполняет только force.
+// Возвращает задачу после переноса или последнее состояние удаленной.
+func (s *TaskServer) DoneTask(ctx context.Context, req *pb.DoneTaskRequest) (*pb.DoneTaskResponse, error) {
+	task, finished, err := s.ts.DoneTask(ctx, int(req.Id), req.Force)
+	if err != nil {
+		return nil, toStatus(err)
 	}
 
-	return &pb.EmptyResponse{}, nil
+	return &pb.DoneTaskResponse{Task: taskToProto(task), Finished: finished}, nil
 }
 
 // UpdateDate обновляет дату задачи
 func (s *TaskServer) UpdateDate(ctx context.Context, req *pb.UpdateDateRequest) (*pb.EmptyResponse, error) {
-	err := s.ts.UpdateDateTask(ctx, req.NextDate, int(req.Id))
+	var remaining *int
+	if req.Remaining != nil {
+		r := int(*req.Remaining)
+		remaining = &r
+	}
+	err := s.ts.UpdateDateTask(ctx, req.NextDate, remaining, int(req.Id))
 	if err != nil {
-		switch {
-		case errors.Is(err, apperrors.ErrInvalidTaskID):
-			return nil, status.Errorf(codes.InvalidArgument, "invalid task id= %d", req.Id)
-		case errors.Is(err, apperrors.ErrDateRequired):
-			return nil, status.Error(codes.InvalidArgument, "date is required")
-		case errors.Is(err, apperrors.ErrTaskNotFound):
-			return nil, status.Errorf(codes.NotFound, "task ad= %d not found", req.Id)
-		default:
-			log.Printf("%v: %v", apperrors.ErrUpdateTaskDate, err)
-			return nil, status.Error(codes.Internal, "failed to update date")
-		}
+		return nil, toStatus(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// SnoozeTask откладывает текущее повторение задачи
+func (s *TaskServer) SnoozeTask(ctx context.Context, req *pb.SnoozeTaskRequest) (*pb.EmptyResponse, error) {
+	err := s.ts.SnoozeTask(ctx, int(req.Id), int(req.Days), req.Date)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.EmptyResponse{}, nil
+}
+
+// SkipOccurrence пропускает повторение задачи
+func (s *TaskServer) SkipOccurrence(ctx context.Context, req *pb.SkipOccurrenceRequest) (*pb.EmptyResponse, error) {
+	err := s.ts.SkipOccurrence(ctx, int(req.Id), req.Date)
+	if err != nil {
+		return nil, toStatus(err)
 	}
 
 	return &pb.EmptyResponse{}, nil
 }
 
+// ListMissed возвращает историю пропущенных повторений задачи
+func (s *TaskServer) ListMissed(ctx context.Context, req *pb.IDRequest) (*pb.ListMissedResponse, error) {
+	missed, err := s.ts.ListMissed(ctx, int(req.Id))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	var pbMissed []*pb.MissedOccurrence
+	for _, m := range missed {
+		pbMissed = append(pbMissed, &pb.MissedOccurrence{
+			Id:        int32(m.ID),
+			TaskId:    int32(m.TaskID),
+			Date:      m.Date,
+			Title:     m.Title,
+			CreatedAt: m.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return &pb.ListMissedResponse{Missed: pbMissed}, nil
+}
+
 // NextDate рассчитывает следующую дату по правилу повторения
 func (s *TaskServer) NextDate(ctx context.Context, req *pb.NextDateRequest) (*pb.NextDateResponse, error) {
-	now := time.Now()
+	loc, err := cm.Location(req.Tz)
+	if err != nil {
+		return nil, toStatus(fmt.Errorf("%w: %w", apperrors.ErrInvalidTimeZone, err))
+	}
+	// текущая дата из запроса, иначе часы сервиса
+	now := s.clock.Now().In(loc)
+	if req.CurrentDate != "" {
+		now, err = time.ParseInLocation(cm.FormDate, req.CurrentDate, loc)
+		if err != nil {
+			return nil, toStatus(fmt.Errorf("%w: current date: %w", apperrors.ErrInvalidDateFormat, err))
+		}
+	}
 	next, err := cm.NextDate(now, req.TaskDate, req.RepeatRule)
 	if err != nil {
-		log.Println("error: ", err)
-		return nil, status.Error(codes.InvalidArgument, "invalid repeat rule or date")
+		return nil, toStatus(fmt.Errorf("%w: %w", apperrors.ErrInvalidRepeat, err))
 	}
 	return &pb.NextDateResponse{NextDate: next}, nil
 }
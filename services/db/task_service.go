@@ -5,39 +5,67 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"sync"
+	"slices"
+	"sort"
+	"time"
 
+	cm "github.com/Vasya-lis/firstWorkWithgRPC/common"
 	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/common/blob"
 	"github.com/Vasya-lis/firstWorkWithgRPC/services/db/cache"
 	"github.com/Vasya-lis/firstWorkWithgRPC/services/db/repo"
 	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
 )
 
 type TasksService struct {
-	tr *repo.TasksRepo
-	tc *cache.TasksCache // подключение к кэшу
-	mu sync.RWMutex
+	tr    *repo.TasksRepo
+	tc    *cache.TasksCache // подключение к кэшу
+	clock cm.Clock
+	blobs blob.Store // содержимое вложений
+
+	maxAttachmentSize int64 // байт
 }
 
-func NewTasksService(tr *repo.TasksRepo, tc *cache.TasksCache) *TasksService {
+func NewTasksService(tr *repo.TasksRepo, tc *cache.TasksCache, clock cm.Clock, blobs blob.Store, maxAttachmentSize int64) *TasksService {
 	return &TasksService{
-		tr: tr,
-		tc: tc,
-		mu: sync.RWMutex{},
+		tr:    tr,
+		tc:    tc,
+		clock: clock,
+		blobs: blobs,
+
+		maxAttachmentSize: maxAttachmentSize,
 	}
 }
 
-func (s *TasksService) GetTasks(ctx context.Context, limit int, search string) ([]*md.Task, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// GetTasks возвращает задачи по фильтру, сначала из кэша, при промахе из бд
+func (s *TasksService) GetTasks(ctx context.Context, filter md.TaskFilter) ([]*md.Task, error) {
+	var err error
+	if filter.Order, err = md.CheckOrder(filter.Order); err != nil {
+		return nil, apperrors.NewFieldError("order", fmt.Errorf("%w: %w", apperrors.ErrInvalidOrder, err))
+	}
+	if filter.MinPriority != md.PriorityUnspecified && !filter.MinPriority.Valid() {
+		return nil, apperrors.NewFieldError("min_priority", apperrors.ErrInvalidPriority)
+	}
+	for _, status := range filter.Statuses {
+		if !status.Valid() {
+			return nil, apperrors.NewFieldError("statuses", apperrors.ErrInvalidStatus)
+		}
+	}
+	if filter.ProjectID < 0 {
+		return nil, apperrors.NewFieldError("project_id", apperrors.ErrProjectNotFound)
+	}
+	if filter.ParentID < 0 {
+		return nil, apperrors.NewFieldError("parent_id", apperrors.ErrParentNotFound)
+	}
+	filter.Tags = normalizeTags(filter.Tags)
 
 	// 1. пробуем из кеша
-	tasks, err := s.tc.GetTasksCache(ctx, limit, search)
+	tasks, err := s.tc.GetTasksCache(ctx, filter)
 	if err != nil {
 		log.Printf("%v: %v", apperrors.ErrGetTasksCache, err)
 
 		// 2. получаем из бд без фильтра
-		tasks, err = s.tr.Tasks(-1, "") // логика репозитория
+		tasks, err = s.tr.Tasks(ctx, md.TaskFilter{})
 		if err != nil {
 			return nil, fmt.Errorf("%w: %w", apperrors.ErrGetTasks, err)
 		}
@@ -48,24 +76,25 @@ func (s *TasksService) GetTasks(ctx context.Context, limit int, search string) (
 			log.Printf("%v: %v", apperrors.ErrSetTasksCache, err)
 		}
 		// фильтруем
-		tasks, err = s.tr.Tasks(limit, search)
+		tasks, err = s.tr.Tasks(ctx, filter)
 		if err != nil {
-			return nil, fmt.Errorf("%w:%w failed to filter tasks with limit=%d search=%s", apperrors.ErrGetTasks, err, limit, search)
+			return nil, fmt.Errorf("%w:%w failed to filter tasks with filter=%+v", apperrors.ErrGetTasks, err, filter)
 		}
 
 	}
+	if err := s.markBlocked(ctx, tasks...); err != nil {
+		return nil, err
+	}
 	return tasks, nil
 }
 func (s *TasksService) GetTask(ctx context.Context, id int) (*md.Task, error) {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
 	// 1. проверяем кэш
 
 	task, err := s.tc.GetTaskCache(ctx, id)
 	if err != nil {
 		log.Printf("%v: %v", apperrors.ErrGetTaskCache, err)
 		// достаем из бд
-		task, err = s.GetTask(ctx, id)
+		task, err = s.tr.GetTask(ctx, id)
 		if err != nil {
 			if errors.Is(err, apperrors.ErrTaskNotFound) {
 				return nil, err
@@ -78,11 +107,37 @@ func (s *TasksService) GetTask(ctx context.Context, id int) (*md.Task, error) {
 		}
 
 	}
+	if err := s.markBlocked(ctx, task); err != nil {
+		return nil, err
+	}
 	return task, nil
 }
 
+// AddTask проверяет и нормализует задачу, затем сохраняет ее
 func (s *TasksService) AddTask(ctx context.Context, task *md.Task) (int, error) {
-	id, err := s.tr.AddTask(task)
+	if err := s.normalizeTask(task, true); err != nil {
+		return 0, err
+	}
+
+	// задача, ее начальный статус в истории и запись журнала сохраняются вместе,
+	// проект и родитель проверяются в той же транзакции
+	var id int
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		if err := checkProject(ctx, tx, task.ProjectID); err != nil {
+			return err
+		}
+		if err := checkParent(ctx, tx, task); err != nil {
+			return err
+		}
+		var err error
+		if id, err = tx.AddTask(ctx, task); err != nil {
+			return err
+		}
+		if err := tx.AddStatusChange(ctx, id, md.StatusUnspecified, task.Status, s.clock.Now()); err != nil {
+			return err
+		}
+		return audit(ctx, tx, md.AuditAdd, id, nil, task)
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -94,51 +149,538 @@ func (s *TasksService) AddTask(ctx context.Context, task *md.Task) (int, error)
 	return id, nil
 }
 
-func (s *TasksService) UpdateTask(ctx context.Context, task *md.Task) error {
-	// обновляем в бд
-	err := s.tr.Updates(task)
+// legacyFields поля задачи в старом API без fields: клиент, который не знает
+// о новых полях, не должен стирать их нулевыми значениями
+var legacyFields = []string{"date", "title", "comment", "repeat"}
+
+// UpdateTask меняет у задачи поля fields (имена как в JSON) значениями из task,
+// пустой fields — только поля старого API (legacyFields). Счетчик remaining сохраняется,
+// пока не изменилось правило повторения: клиент не может сбросить или продлить серию.
+func (s *TasksService) UpdateTask(ctx context.Context, task *md.Task, fields []string) error {
+	if task.ID <= 0 {
+		return apperrors.NewFieldError("id", apperrors.ErrInvalidTaskID)
+	}
+	if len(fields) == 0 {
+		fields = legacyFields
+	}
+	// дата переносится на актуальную, только если клиент менял дату или правило
+	moveDate := slices.Contains(fields, "date") || slices.Contains(fields, "repeat")
+	_, err := s.updateTask(ctx, task.ID, md.AuditUpdate, func(current *md.Task) (*md.Task, bool) {
+		updated := current.Clone()
+		current.Snapshot().Merge(task.Snapshot(), fields).Apply(updated)
+		if updated.Repeat == current.Repeat {
+			updated.Remaining = current.Remaining
+		} else {
+			// новое правило — счетчик начинается с полного count
+			updated.Remaining = 0
+		}
+		return updated, moveDate
+	})
+	return err
+}
+
+// updateTask меняет задачу id под блокировкой строки: edit получает текущую задачу
+// и возвращает измененную копию и признак переноса прошедшей даты (см. normalizeTask),
+// копия проверяется и нормализуется, прежняя версия сохраняется. action — действие
+// для журнала аудита. Возвращает задачу после обновления.
+func (s *TasksService) updateTask(ctx context.Context, id int, action string, edit func(current *md.Task) (*md.Task, bool)) (*md.Task, error) {
+	// обновляем в бд и перечитываем задачу: статус и зависимости запрос не меняет
+	var task *md.Task
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		current, err := tx.GetTaskForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		var moveDate bool
+		task, moveDate = edit(current)
+		if err := s.normalizeTask(task, moveDate); err != nil {
+			return err
+		}
+		if err := checkProject(ctx, tx, task.ProjectID); err != nil {
+			return err
+		}
+		if err := checkParent(ctx, tx, task); err != nil {
+			return err
+		}
+
+		// версия без изменений не нужна
+		changes, err := md.Diff(current.Snapshot(), task.Snapshot())
+		if err != nil {
+			return err
+		}
+		if len(changes) > 0 {
+			if err := saveRevision(ctx, tx, current); err != nil {
+				return err
+			}
+		}
+		if err := tx.Updates(ctx, task); err != nil {
+			return err
+		}
+		if task, err = tx.GetTask(ctx, id); err != nil {
+			return err
+		}
+		return audit(ctx, tx, action, id, current, task)
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// обновляем кэш
-
-	if err := s.tc.SetTaskCache(ctx, task.ID, task); err != nil {
-		log.Printf("%v: %v", apperrors.ErrSetTaskCache, err)
+	s.applyCache(ctx, &cacheUpdate{set: []*md.Task{task}})
+	if err := s.markBlocked(ctx, task); err != nil {
+		return nil, err
 	}
-	return nil
+	return task, nil
 }
 
+// DeleteTask удаляет задачу вместе с подзадачами
 func (s *TasksService) DeleteTask(ctx context.Context, id int) error {
+	changes := &cacheUpdate{}
 	// удаляем из базы
-	err := s.tr.DeleteTask(id)
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		task, err := tx.GetTaskForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := deleteWithSubtasks(ctx, tx, id, changes); err != nil {
+			return err
+		}
+		// в журнал попадает сама задача, подзадачи удаляются вместе с ней
+		return audit(ctx, tx, md.AuditDelete, id, task, nil)
+	})
 	if err != nil {
 		log.Printf("%v: %v", apperrors.ErrDeleteTask, err)
 		return err
 	}
 	// удаляем из кэша
-	s.tc.DeleteTaskCache(ctx, id)
+	s.applyCache(ctx, changes)
 	return nil
 }
 
-func (s *TasksService) UpdateDateTask(ctx context.Context, next string, id int) error {
-	err := s.tr.UpdateDate(next, id)
+// deleteWithSubtasks удаляет задачу, подзадачи и вложения удаляет база каскадно,
+// id подзадач нужны для кэша, ключи вложений — для удаления содержимого
+func deleteWithSubtasks(ctx context.Context, tx *repo.TasksRepo, id int, changes *cacheUpdate) error {
+	subtasks, _, err := tx.Descendants(ctx, id)
+	if err != nil {
+		return err
+	}
+	deleted := append([]int{id}, subtasks...)
+	dependents, err := tx.Dependents(ctx, deleted)
+	if err != nil {
+		return err
+	}
+	keys, err := tx.AttachmentKeys(ctx, deleted)
+	if err != nil {
+		return err
+	}
+	if err := tx.DeleteTask(ctx, id); err != nil {
+		return err
+	}
+	changes.deleted = append(changes.deleted, deleted...)
+	changes.blobs = append(changes.blobs, keys...)
+	return reloadDependents(ctx, tx, dependents, deleted, changes)
+}
+
+// UpdateDateTask переносит задачу на дату next и возвращает ее из той же транзакции.
+// remaining nil — счетчик серии сохраняется: клиент, не знающий о count, не должен
+// превращать ограниченную серию в бесконечную.
+func (s *TasksService) UpdateDateTask(ctx context.Context, next string, remaining *int, id int) error {
+	// шаблон proto пропускает несуществующие даты вроде 20241399
+	if _, err := time.Parse(cm.FormDate, next); err != nil {
+		return apperrors.NewFieldError("next_date", fmt.Errorf("%w: %w", apperrors.ErrInvalidDateFormat, err))
+	}
+
+	var task *md.Task
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		current, err := tx.GetTaskForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		left := current.Remaining
+		if remaining != nil {
+			left = *remaining
+			if err := checkRemaining(current.Repeat, left); err != nil {
+				return err
+			}
+		}
+		if err := saveRevision(ctx, tx, current); err != nil {
+			return err
+		}
+		if err := tx.UpdateDate(ctx, next, left, id); err != nil {
+			return err
+		}
+		if task, err = tx.GetTask(ctx, id); err != nil {
+			return err
+		}
+		return audit(ctx, tx, md.AuditUpdateDate, id, current, task)
+	})
 	if err != nil {
 		log.Printf("%v: %v", apperrors.ErrUpdateTaskDate, err)
 		return err
 	}
 
-	// получаем обновленную задачу из бд
-	task, err := s.tr.GetTask(id)
+	// обновляем кэш
+	s.applyCache(ctx, &cacheUpdate{set: []*md.Task{task}})
+	return nil
+}
+
+// DoneTask выполняет задачу: одноразовую или исчерпавшую серию удаляет, повторяющуюся переносит.
+// Чтение, расчет следующей даты и запись идут в одной транзакции под блокировкой строки,
+// поэтому две реплики не перенесут задачу дважды. Задачу с невыполненными зависимостями
+// выполняет только force. Возвращает задачу после выполнения и признак finished,
+// если серия завершена и задача удалена.
+func (s *TasksService) DoneTask(ctx context.Context, id int, force bool) (*md.Task, bool, error) {
+	changes := &cacheUpdate{}
+	var task *md.Task
+	var finished bool
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		var err error
+		task, err = tx.GetTaskForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := checkDone(task); err != nil {
+			return err
+		}
+		before := task.Clone()
+		if !force {
+			if err := checkBlocked(ctx, tx, task); err != nil {
+				return err
+			}
+		}
+
+		now, err := cm.NowIn(s.clock.Now(), task.TZ)
+		if err != nil {
+			return fmt.Errorf("%w: %w", apperrors.ErrInvalidRepeat, err)
+		}
+		// отложенное повторение считаем от даты по расписанию, но повторения
+		// внутри отсрочки пропущенными не считаются
+		base, err := missedFrom(task)
+		if err != nil {
+			return fmt.Errorf("%w: %w", apperrors.ErrInvalidRepeat, err)
+		}
+		// повторения между выполненным и сегодняшним днем расходуют счетчик count,
+		// в режиме completion их нет: следующее считается от дня выполнения
+		var missed []string
+		current, remaining := base, task.Remaining
+		if task.RepeatMode != cm.RepeatModeCompletion {
+			missed, remaining, err = cm.MissedOccurrences(base, now, task.Repeat, task.Remaining, task.Exceptions)
+			if err != nil {
+				return fmt.Errorf("%w: %w", apperrors.ErrInvalidRepeat, err)
+			}
+			if len(missed) > 0 {
+				current = missed[len(missed)-1]
+			}
+		}
+		var next string
+		var left int
+		next, left, finished, err = cm.NextOccurrence(now, current, task.Repeat, task.RepeatMode, remaining, task.Exceptions)
+		if err != nil {
+			return fmt.Errorf("%w: %w", apperrors.ErrInvalidRepeat, err)
+		}
+		// удаляемой задаче версии не нужны, они удаляются вместе с ней
+		if !finished {
+			if err := saveRevision(ctx, tx, task); err != nil {
+				return err
+			}
+		}
+
+		// пропущенные повторения не теряем, если задана политика
+		if catchesUp(task) {
+			if err := s.recordMissed(ctx, tx, task, missed, changes); err != nil {
+				return err
+			}
+		}
+
+		// исчерпанная серия удаляется вместе с подзадачами
+		if err := advance(ctx, tx, task, next, left, finished, changes); err != nil {
+			return err
+		}
+		if finished {
+			return audit(ctx, tx, md.AuditDone, id, before, nil)
+		}
+		// у следующего повторения подзадачи начинаются заново, как и чек-лист
+		if err := s.resetSubtasks(ctx, tx, task.ID, changes); err != nil {
+			return err
+		}
+		// следующее повторение начинается заново
+		if task.Status != md.StatusTodo {
+			if err := s.changeStatus(ctx, tx, task, md.StatusTodo); err != nil {
+				return err
+			}
+		}
+		if err := refreshTask(ctx, tx, task); err != nil {
+			return err
+		}
+		return audit(ctx, tx, md.AuditDone, id, before, task)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	s.applyCache(ctx, changes)
+	return task, finished, nil
+}
+
+// advance переносит задачу на следующее повторение или удаляет исчерпанную серию
+func advance(ctx context.Context, tx *repo.TasksRepo, task *md.Task, next string, left int, finished bool, changes *cacheUpdate) error {
+	if finished {
+		if err := deleteWithSubtasks(ctx, tx, task.ID, changes); err != nil {
+			log.Printf("%v: %v", apperrors.ErrDeleteTask, err)
+			return err
+		}
+		return nil
+	}
+
+	if err := tx.UpdateDate(ctx, next, left, task.ID); err != nil {
+		log.Printf("%v: %v", apperrors.ErrUpdateTaskDate, err)
+		return err
+	}
+	// у следующего повторения чек-лист начинается заново
+	if task.Checklist.HasDone() {
+		task.Checklist = task.Checklist.Reset()
+		if err := tx.SetChecklist(ctx, task.ID, task.Checklist); err != nil {
+			return err
+		}
+	}
+	task.Date = next
+	task.Remaining = left
+	task.Anchor = ""
+	changes.set = append(changes.set, task)
+	return nil
+}
+
+// resetSubtasks возвращает подзадачи всех уровней в todo и снимает отметки их чек-листов.
+// Даты подзадач не меняются: повторяющиеся подзадачи переносятся своим выполнением.
+func (s *TasksService) resetSubtasks(ctx context.Context, tx *repo.TasksRepo, id int, changes *cacheUpdate) error {
+	subtasks, _, err := tx.Descendants(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, subID := range subtasks {
+		task, err := tx.GetTaskForUpdate(ctx, subID)
+		if err != nil {
+			return err
+		}
+		if task.Status == md.StatusTodo && !task.Checklist.HasDone() {
+			continue
+		}
+		before := task.Clone()
+		if task.Checklist.HasDone() {
+			if err := saveRevision(ctx, tx, task); err != nil {
+				return err
+			}
+			task.Checklist = task.Checklist.Reset()
+			if err := tx.SetChecklist(ctx, task.ID, task.Checklist); err != nil {
+				return err
+			}
+		}
+		if task.Status != md.StatusTodo {
+			if err := s.changeStatus(ctx, tx, task, md.StatusTodo); err != nil {
+				return err
+			}
+		}
+		if err := refreshTask(ctx, tx, task); err != nil {
+			return err
+		}
+		if err := audit(ctx, tx, md.AuditReset, task.ID, before, task); err != nil {
+			return err
+		}
+		changes.set = append(changes.set, task)
+	}
+	return nil
+}
+
+// SnoozeTask откладывает текущее повторение на days дней или на дату date, не меняя правило.
+// Дата date не может быть раньше даты задачи и раньше сегодняшнего дня.
+func (s *TasksService) SnoozeTask(ctx context.Context, id, days int, date string) error {
+	var task *md.Task
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		var err error
+		task, err = tx.GetTaskForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case date != "":
+			snoozed, err := time.Parse(cm.FormDate, date)
+			if err != nil {
+				return fmt.Errorf("%w: %w", apperrors.ErrInvalidDateFormat, err)
+			}
+			now, err := cm.NowIn(s.clock.Now(), task.TZ)
+			if err != nil {
+				return fmt.Errorf("%w: %w", apperrors.ErrInvalidRepeat, err)
+			}
+			// отложить можно только вперед: не раньше даты задачи и не в прошлое
+			if date < task.Date || cm.AfterNow(now, snoozed) {
+				return apperrors.ErrInvalidParameter.With("name", "date")
+			}
+		case days > 0:
+			current, err := time.Parse(cm.FormDate, task.Date)
+			if err != nil {
+				return fmt.Errorf("%w: %w", apperrors.ErrInvalidDateFormat, err)
+			}
+			date = current.AddDate(0, 0, days).Format(cm.FormDate)
+		default:
+			return apperrors.ErrSnoozeRequired
+		}
+
+		if err := saveRevision(ctx, tx, task); err != nil {
+			return err
+		}
+
+		// запоминаем дату по расписанию, чтобы серия не сдвинулась
+		anchor := task.Anchor
+		if anchor == "" && task.Repeat != "" {
+			anchor = task.Date
+		}
+
+		before := task.Clone()
+		if err := tx.Snooze(ctx, id, date, anchor); err != nil {
+			log.Printf("%v: %v", apperrors.ErrSnoozeTask, err)
+			return err
+		}
+		if err := refreshTask(ctx, tx, task); err != nil {
+			return err
+		}
+		return audit(ctx, tx, md.AuditSnooze, id, before, task)
+	})
 	if err != nil {
-		log.Printf("%v: %v", apperrors.ErrGetTask, err)
 		return err
 	}
 
 	// обновляем кэш
+	s.applyCache(ctx, &cacheUpdate{set: []*md.Task{task}})
+	return nil
+}
 
-	if err := s.tc.SetTaskCache(ctx, task.ID, task); err != nil {
-		log.Printf("%v: %v", apperrors.ErrSetTaskCache, err)
+// SkipOccurrence пропускает повторение серии: текущее (date пустая) переносит задачу
+// на следующее, будущее добавляет в исключения. Дата в прошлом или не приходящаяся
+// на повторение серии — ошибка, повторный пропуск той же даты ничего не меняет.
+func (s *TasksService) SkipOccurrence(ctx context.Context, id int, date string) error {
+	changes := &cacheUpdate{}
+	err := s.tr.InTx(ctx, func(tx *repo.TasksRepo) error {
+		task, err := tx.GetTaskForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if task.Repeat == "" {
+			return apperrors.ErrNotRepeating
+		}
+		before := task.Clone()
+
+		now, err := cm.NowIn(s.clock.Now(), task.TZ)
+		if err != nil {
+			return fmt.Errorf("%w: %w", apperrors.ErrInvalidRepeat, err)
+		}
+
+		current := task.Date
+		if task.Anchor != "" {
+			current = task.Anchor
+		}
+
+		// будущее повторение — только запоминаем исключение
+		if date != "" && date != current && date != task.Date {
+			if _, err := time.Parse(cm.FormDate, date); err != nil {
+				return fmt.Errorf("%w: %w", apperrors.ErrInvalidDateFormat, err)
+			}
+			if date < now.Format(cm.FormDate) {
+				return apperrors.ErrInvalidParameter.With("name", "date")
+			}
+			// повторение уже пропущено — задача не меняется, ревизию и аудит не пишем
+			if slices.Contains(task.Exceptions, date) {
+				return nil
+			}
+			ok, err := cm.IsOccurrence(current, date, task.Repeat, task.RepeatMode, task.Remaining, task.Exceptions)
+			if err != nil {
+				return fmt.Errorf("%w: %w", apperrors.ErrInvalidRepeat, err)
+			}
+			if !ok {
+				return apperrors.ErrInvalidParameter.With("name", "date")
+			}
+			if err := saveRevision(ctx, tx, task); err != nil {
+				return err
+			}
+			task.Exceptions = addException(task.Exceptions, date, now)
+			if err := tx.SetExceptions(ctx, id, task.Exceptions); err != nil {
+				log.Printf("%v: %v", apperrors.ErrSkipOccurrence, err)
+				return err
+			}
+			changes.set = append(changes.set, task)
+			if err := refreshTask(ctx, tx, task); err != nil {
+				return err
+			}
+			return audit(ctx, tx, md.AuditSkip, id, before, task)
+		}
+
+		// текущее повторение — переходим к следующему, пропуск расходует повторение
+		exceptions := addException(task.Exceptions, current, now)
+		next, left, finished, err := cm.NextOccurrence(now, current, task.Repeat, cm.RepeatModeSchedule, task.Remaining, exceptions)
+		if err != nil {
+			return fmt.Errorf("%w: %w", apperrors.ErrInvalidRepeat, err)
+		}
+		if finished {
+			if err := advance(ctx, tx, task, next, left, finished, changes); err != nil {
+				return err
+			}
+			return audit(ctx, tx, md.AuditSkip, id, before, nil)
+		}
+		if err := saveRevision(ctx, tx, task); err != nil {
+			return err
+		}
+		if err := tx.SetExceptions(ctx, id, exceptions); err != nil {
+			log.Printf("%v: %v", apperrors.ErrSkipOccurrence, err)
+			return err
+		}
+		task.Exceptions = exceptions
+		if err := advance(ctx, tx, task, next, left, finished, changes); err != nil {
+			return err
+		}
+		if err := refreshTask(ctx, tx, task); err != nil {
+			return err
+		}
+		return audit(ctx, tx, md.AuditSkip, id, before, task)
+	})
+	if err != nil {
+		return err
 	}
 
+	s.applyCache(ctx, changes)
 	return nil
 }
+
+// addException добавляет дату в исключения и убирает прошедшие, они уже не влияют на серию
+func addException(exceptions md.DateList, date string, now time.Time) md.DateList {
+	today := now.Format(cm.FormDate)
+	result := md.DateList{}
+	for _, d := range exceptions {
+		if d >= today && d != date {
+			result = append(result, d)
+		}
+	}
+	if date >= today {
+		result = append(result, date)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// cacheUpdate изменения кэша, которые применяются только после фиксации транзакции.
+// Туда же попадает содержимое удаленных вложений: откаченное удаление его не трогает.
+type cacheUpdate struct {
+	set     []*md.Task
+	deleted []int
+	blobs   []string // ключи содержимого вложений для удаления из хранилища
+}
+
+func (s *TasksService) applyCache(ctx context.Context, changes *cacheUpdate) {
+	for _, task := range changes.set {
+		if err := s.tc.SetTaskCache(ctx, task.ID, task); err != nil {
+			log.Printf("%v: %v", apperrors.ErrSetTaskCache, err)
+		}
+	}
+	for _, id := range changes.deleted {
+		s.tc.DeleteTaskCache(ctx, id)
+	}
+	s.deleteBlobs(ctx, changes.blobs...)
+}
@@ -0,0 +1,857 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cm "github.com/Vasya-lis/firstWorkWithgRPC/common"
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/common/blob"
+	"github.com/Vasya-lis/firstWorkWithgRPC/services/db/cache"
+	"github.com/Vasya-lis/firstWorkWithgRPC/services/db/repo"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testService сервис на пустой тестовой базе, часы заморожены на 26.01.2024 12:00 UTC.
+// Тест пропускается без TEST_DATABASE_DSN. Кэш — TEST_REDIS_ADDR (по умолчанию localhost:6379),
+// без redis ошибки кэша только логируются, как и в сервисе.
+func testService(t testing.TB) (*TasksService, *cm.FrozenClock) {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_DSN")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	if err := db.AutoMigrate(&md.Project{}, &md.Task{}, &md.MissedOccurrence{}, &md.Tag{}, &md.TaskTag{}, &md.TaskDependency{}, &md.StatusChange{}, &md.TaskRevision{}, &md.AuditEvent{}, &md.Attachment{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if err := db.Exec("TRUNCATE projects, tasks, tags, audit_events RESTART IDENTITY CASCADE").Error; err != nil {
+		t.Fatalf("truncate: %v", err)
+	}
+
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	t.Cleanup(func() { client.Close() })
+	tc := cache.NewTasksCache(client)
+	tc.ClearTaskCache(context.Background())
+
+	clock := &cm.FrozenClock{}
+	clock.Freeze(time.Date(2024, 1, 26, 12, 0, 0, 0, time.UTC))
+	return NewTasksService(repo.NewTasksRepo(db, clock), tc, clock, nil, 0), clock
+}
+
+// addTestTask добавляет задачу через сервис и возвращает ее id
+func addTestTask(t testing.TB, s *TasksService, task *md.Task) int {
+	t.Helper()
+	id, err := s.AddTask(context.Background(), task)
+	if err != nil {
+		t.Fatalf("AddTask() error: %v", err)
+	}
+	return id
+}
+
+func TestSnoozeTask(t *testing.T) {
+	s, clock := testService(t)
+	ctx := context.Background()
+
+	cases := []struct {
+		name    string
+		date    string
+		now     string // сегодня на момент переноса
+		days    int
+		snoozed string
+		want    string // пустая — ожидается ErrInvalidParameter
+	}{
+		{name: "days", date: "20240130", now: "20240126", days: 3, want: "20240202"},
+		{name: "explicit", date: "20240130", now: "20240126", snoozed: "20240205", want: "20240205"},
+		{name: "same day", date: "20240130", now: "20240126", snoozed: "20240130", want: "20240130"},
+		{name: "today for overdue", date: "20240126", now: "20240201", snoozed: "20240201", want: "20240201"},
+		{name: "before task date", date: "20240130", now: "20240126", snoozed: "20240128"},
+		{name: "in the past", date: "20240126", now: "20240201", snoozed: "20240131"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock.Freeze(time.Date(2024, 1, 26, 12, 0, 0, 0, time.UTC))
+			id := addTestTask(t, s, &md.Task{Date: tc.date, Title: tc.name})
+			now, err := time.Parse(cm.FormDate, tc.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			clock.Freeze(now.Add(12 * time.Hour))
+
+			err = s.SnoozeTask(ctx, id, tc.days, tc.snoozed)
+			if tc.want == "" {
+				if !errors.Is(err, apperrors.ErrInvalidParameter) {
+					t.Fatalf("SnoozeTask() error = %v, want ErrInvalidParameter", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SnoozeTask() error: %v", err)
+			}
+			task, err := s.GetTask(ctx, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if task.Date != tc.want {
+				t.Errorf("date after snooze = %q, want %q", task.Date, tc.want)
+			}
+		})
+	}
+}
+
+func TestSkipOccurrence(t *testing.T) {
+	s, _ := testService(t)
+	ctx := context.Background()
+	id := addTestTask(t, s, &md.Task{Date: "20240126", Title: "weekly", Repeat: "d 7"})
+
+	// записи истории и журнала по задаче
+	history := func() (int, int) {
+		t.Helper()
+		revisions, err := s.ListRevisions(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		events, err := s.ListAuditEvents(ctx, md.AuditFilter{TaskID: id, Action: md.AuditSkip})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(revisions), len(events)
+	}
+
+	for _, date := range []string{"20240119", "20240203", "20240201"} {
+		if err := s.SkipOccurrence(ctx, id, date); !errors.Is(err, apperrors.ErrInvalidParameter) {
+			t.Errorf("SkipOccurrence(%s) error = %v, want ErrInvalidParameter", date, err)
+		}
+	}
+	if revisions, events := history(); revisions != 0 || events != 0 {
+		t.Fatalf("after rejected skips: %d revisions, %d audit events, want none", revisions, events)
+	}
+
+	if err := s.SkipOccurrence(ctx, id, "20240209"); err != nil {
+		t.Fatalf("SkipOccurrence() error: %v", err)
+	}
+	// повторный пропуск той же даты ничего не меняет
+	if err := s.SkipOccurrence(ctx, id, "20240209"); err != nil {
+		t.Fatalf("SkipOccurrence() again error: %v", err)
+	}
+	if revisions, events := history(); revisions != 1 || events != 1 {
+		t.Errorf("after skipping twice: %d revisions, %d audit events, want 1 and 1", revisions, events)
+	}
+
+	task, err := s.GetTask(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(task.Exceptions) != 1 || task.Exceptions[0] != "20240209" {
+		t.Errorf("exceptions = %v, want [20240209]", task.Exceptions)
+	}
+}
+
+// пропущенные повторения расходуют счетчик count при выполнении и при догоне
+func TestDoneTaskCountsMissed(t *testing.T) {
+	s, clock := testService(t)
+	ctx := context.Background()
+
+	cases := []struct {
+		name      string
+		repeat    string
+		catchUp   string
+		catchUpFn bool // обработать задачу фоновым догоном, а не выполнением
+		next      string
+		remaining int
+		finished  bool
+	}{
+		{name: "done", repeat: "d 7 count 5", next: "20240216", remaining: 2},
+		{name: "done exhausted", repeat: "d 7 count 3", finished: true},
+		{name: "done history", repeat: "d 7 count 5", catchUp: cm.CatchUpHistory, next: "20240216", remaining: 2},
+		{name: "catch-up", repeat: "d 7 count 5", catchUp: cm.CatchUpHistory, catchUpFn: true, next: "20240216", remaining: 2},
+		{name: "catch-up exhausted", repeat: "d 7 count 3", catchUp: cm.CatchUpHistory, catchUpFn: true, finished: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock.Freeze(time.Date(2024, 1, 26, 12, 0, 0, 0, time.UTC))
+			id := addTestTask(t, s, &md.Task{Date: "20240126", Title: tc.name, Repeat: tc.repeat, CatchUp: tc.catchUp})
+			// пропущены 26.01, 02.02 и 09.02
+			clock.Freeze(time.Date(2024, 2, 10, 12, 0, 0, 0, time.UTC))
+
+			if tc.catchUpFn {
+				if err := s.catchUpTask(ctx, id); err != nil {
+					t.Fatalf("catchUpTask() error: %v", err)
+				}
+			} else {
+				if _, _, err := s.DoneTask(ctx, id, false); err != nil {
+					t.Fatalf("DoneTask() error: %v", err)
+				}
+			}
+
+			task, err := s.GetTask(ctx, id)
+			if tc.finished {
+				if !errors.Is(err, apperrors.ErrTaskNotFound) {
+					t.Fatalf("GetTask() error = %v, want ErrTaskNotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if task.Date != tc.next || task.Remaining != tc.remaining {
+				t.Errorf("task = (%q, %d), want (%q, %d)", task.Date, task.Remaining, tc.next, tc.remaining)
+			}
+		})
+	}
+}
+
+// BenchmarkDoneTask выполнение задачи из нескольких горутин. Все горутины на одной
+// задаче ждут блокировку строки FOR UPDATE друг друга, на разных задачах — не мешают.
+//
+//	TEST_DATABASE_DSN=... go test ./services/db -run '^$' -bench DoneTask -cpu 1,4,16
+//
+// В CI (.github/workflows/test.yml) бенчмарк идет на каждый push, результат — артефакт bench.
+func BenchmarkDoneTask(b *testing.B) {
+	s, _ := testService(b)
+	ctx := context.Background()
+
+	b.Run("hot", func(b *testing.B) {
+		id := addTestTask(b, s, &md.Task{Date: "20240126", Title: "hot", Repeat: "d 1"})
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				if _, _, err := s.DoneTask(ctx, id, false); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		})
+	})
+
+	b.Run("disjoint", func(b *testing.B) {
+		// у каждой горутины своя задача
+		var ids []int
+		for i := 0; i < runtime.GOMAXPROCS(0); i++ {
+			ids = append(ids, addTestTask(b, s, &md.Task{Date: "20240126", Title: "disjoint", Repeat: "d 1"}))
+		}
+		var next atomic.Int32
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			id := ids[int(next.Add(1)-1)%len(ids)]
+			for pb.Next() {
+				if _, _, err := s.DoneTask(ctx, id, false); err != nil {
+					b.Error(err)
+					return
+				}
+			}
+		})
+	})
+}
+
+func TestUpdateTaskPartial(t *testing.T) {
+	s, _ := testService(t)
+	ctx := context.Background()
+	id := addTestTask(t, s, &md.Task{
+		Date: "20240130", Title: "report", Comment: "monthly", Repeat: "d 7 count 5",
+		Priority: md.PriorityHigh, Tags: []string{"work"},
+	})
+	// одно повторение уже выполнено
+	if _, _, err := s.DoneTask(ctx, id, false); err != nil {
+		t.Fatal(err)
+	}
+
+	get := func() *md.Task {
+		t.Helper()
+		task, err := s.GetTask(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return task
+	}
+
+	// непереданные поля не затираются, счетчик клиент не меняет
+	if err := s.UpdateTask(ctx, &md.Task{ID: id, Title: "weekly report", Remaining: 99}, []string{"id", "title", "remaining"}); err != nil {
+		t.Fatalf("UpdateTask() error: %v", err)
+	}
+	task := get()
+	if task.Title != "weekly report" || task.Comment != "monthly" || task.Priority != md.PriorityHigh ||
+		task.Repeat != "d 7 count 5" || task.Date != "20240206" || task.Remaining != 4 ||
+		len(task.Tags) != 1 || task.Tags[0] != "work" {
+		t.Errorf("after partial update: %+v", task)
+	}
+
+	// старый клиент без fields передает только date, title, comment и repeat:
+	// остальные поля не затираются, при том же правиле счетчик сохраняется
+	legacy := &md.Task{ID: id, Date: task.Date, Title: "report v2", Comment: task.Comment, Repeat: task.Repeat}
+	if err := s.UpdateTask(ctx, legacy, nil); err != nil {
+		t.Fatalf("UpdateTask() error: %v", err)
+	}
+	if task := get(); task.Title != "report v2" || task.Remaining != 4 || task.Priority != md.PriorityHigh ||
+		len(task.Tags) != 1 || task.Tags[0] != "work" {
+		t.Errorf("after legacy update: %+v", task)
+	}
+
+	// новое правило — счетчик с полного count
+	if err := s.UpdateTask(ctx, &md.Task{ID: id, Repeat: "d 7 count 3"}, []string{"repeat"}); err != nil {
+		t.Fatalf("UpdateTask() error: %v", err)
+	}
+	if task := get(); task.Remaining != 3 || task.Title != "report v2" {
+		t.Errorf("after rule change: remaining %d, title %q", task.Remaining, task.Title)
+	}
+}
+
+// выполнение повторяющегося родителя начинает подзадачи заново, исчерпанного — удаляет
+func TestDoneTaskSubtasks(t *testing.T) {
+	s, _ := testService(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		repeat   string
+		finished bool
+	}{
+		{repeat: "d 7"},
+		{repeat: "", finished: true},
+	} {
+		parent := addTestTask(t, s, &md.Task{Date: "20240126", Title: "parent", Repeat: tc.repeat})
+		sub := addTestTask(t, s, &md.Task{
+			Date: "20240126", Title: "sub", ParentID: &parent,
+			Checklist: md.Checklist{{Title: "a", Done: true}, {Title: "b"}},
+		})
+		weekly := addTestTask(t, s, &md.Task{Date: "20240127", Title: "weekly sub", Repeat: "w 6", ParentID: &sub})
+		if _, err := s.SetStatus(ctx, sub, md.StatusInProgress); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := s.DoneTask(ctx, parent, false); err != nil {
+			t.Fatalf("DoneTask() error: %v", err)
+		}
+
+		for _, id := range []int{sub, weekly} {
+			task, err := s.GetTask(ctx, id)
+			if tc.finished {
+				if !errors.Is(err, apperrors.ErrTaskNotFound) {
+					t.Errorf("subtask %d after finished parent: error = %v, want ErrTaskNotFound", id, err)
+				}
+				continue
+			}
+			if err != nil {
+				t.Fatalf("subtask %d deleted with repeating parent: %v", id, err)
+			}
+			if task.Status != md.StatusTodo || task.Checklist.HasDone() {
+				t.Errorf("subtask %d not reset: status %v, checklist %v", id, task.Status, task.Checklist)
+			}
+			// дата подзадачи своя, родитель ее не переносит
+			if id == weekly && task.Date != "20240127" {
+				t.Errorf("weekly subtask date = %q, want 20240127", task.Date)
+			}
+		}
+	}
+}
+
+// история статусов начинается с начального статуса созданной задачи
+func TestAddTaskStatusHistory(t *testing.T) {
+	s, _ := testService(t)
+	ctx := context.Background()
+
+	for _, status := range []md.Status{md.StatusUnspecified, md.StatusWaiting} {
+		id := addTestTask(t, s, &md.Task{Title: "status", Status: status})
+		changes, err := s.ListStatusChanges(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := status
+		if want == md.StatusUnspecified {
+			want = md.StatusTodo
+		}
+		if len(changes) != 1 || changes[0].From != md.StatusUnspecified || changes[0].To != want {
+			t.Errorf("status %v: history = %+v, want one change to %v", status, changes, want)
+		}
+	}
+}
+
+func TestCatchUpAudit(t *testing.T) {
+	s, clock := testService(t)
+	ctx := context.Background()
+
+	cases := []struct {
+		name     string
+		repeat   string
+		finished bool
+	}{
+		{name: "moved", repeat: "d 7"},
+		{name: "exhausted", repeat: "d 7 count 3", finished: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock.Freeze(time.Date(2024, 1, 26, 12, 0, 0, 0, time.UTC))
+			id := addTestTask(t, s, &md.Task{Date: "20240126", Title: tc.name, Repeat: tc.repeat, CatchUp: cm.CatchUpMaterialize})
+			// пропущены 26.01, 02.02 и 09.02
+			clock.Freeze(time.Date(2024, 2, 10, 12, 0, 0, 0, time.UTC))
+			if err := s.catchUpTask(ctx, id); err != nil {
+				t.Fatalf("catchUpTask() error: %v", err)
+			}
+
+			events, err := s.ListAuditEvents(ctx, md.AuditFilter{TaskID: id, Action: md.AuditCatchUp})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(events) != 1 || events[0].Before == nil || (events[0].After == nil) != tc.finished {
+				t.Fatalf("catch-up events = %+v, want one with before and after=%v", events, !tc.finished)
+			}
+
+			// разовые задачи пропущенных дат записаны как добавленные после самой серии
+			created, err := s.ListAuditEvents(ctx, md.AuditFilter{TaskID: id, Action: md.AuditAdd})
+			if err != nil || len(created) != 1 {
+				t.Fatalf("series add events = %+v, %v", created, err)
+			}
+			added, err := s.ListAuditEvents(ctx, md.AuditFilter{Action: md.AuditAdd, AfterID: created[0].ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			oneOffs := 0
+			for _, e := range added {
+				if e.Before == nil && e.After != nil {
+					oneOffs++
+				}
+			}
+			if len(added) != 3 || oneOffs != 3 {
+				t.Errorf("one-off add events = %d of %d, want 3", oneOffs, len(added))
+			}
+		})
+	}
+}
+
+func TestSnoozedCatchUp(t *testing.T) {
+	s, clock := testService(t)
+	ctx := context.Background()
+
+	cases := []struct {
+		name      string
+		done      bool   // выполнить задачу, а не обработать фоновым догоном
+		now       string // день обработки
+		date      string
+		anchor    string
+		remaining int
+		missed    []string
+	}{
+		// отложенная дата не прошла — задача не меняется
+		{name: "catch-up before snoozed date", now: "20240130", date: "20240205", anchor: "20240126", remaining: 5},
+		// 02.02 внутри отсрочки, пропущены отложенное повторение и 09.02
+		{name: "catch-up after snoozed date", now: "20240210", date: "20240216", remaining: 3, missed: []string{"20240205", "20240209"}},
+		// выполнение в отложенный день: повторения внутри отсрочки не пропущены
+		{name: "done on snoozed date", done: true, now: "20240205", date: "20240209", remaining: 4},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock.Freeze(time.Date(2024, 1, 26, 12, 0, 0, 0, time.UTC))
+			id := addTestTask(t, s, &md.Task{Date: "20240126", Title: tc.name, Repeat: "d 7 count 5", CatchUp: cm.CatchUpHistory})
+			if err := s.SnoozeTask(ctx, id, 0, "20240205"); err != nil {
+				t.Fatalf("SnoozeTask() error: %v", err)
+			}
+
+			now, err := time.Parse(cm.FormDate, tc.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			clock.Freeze(now.Add(12 * time.Hour))
+			if tc.done {
+				_, _, err = s.DoneTask(ctx, id, false)
+			} else {
+				err = s.catchUpTask(ctx, id)
+			}
+			if err != nil {
+				t.Fatalf("error: %v", err)
+			}
+
+			task, err := s.GetTask(ctx, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if task.Date != tc.date || task.Anchor != tc.anchor || task.Remaining != tc.remaining {
+				t.Errorf("task = (%q, anchor %q, %d), want (%q, anchor %q, %d)",
+					task.Date, task.Anchor, task.Remaining, tc.date, tc.anchor, tc.remaining)
+			}
+			records, err := s.ListMissed(ctx, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var missed []string
+			for _, r := range records {
+				missed = append(missed, r.Date)
+			}
+			if !slices.Equal(missed, tc.missed) {
+				t.Errorf("missed = %v, want %v", missed, tc.missed)
+			}
+		})
+	}
+}
+
+func TestUpdateTaskOverdueDate(t *testing.T) {
+	s, clock := testService(t)
+	ctx := context.Background()
+
+	cases := []struct {
+		name   string
+		repeat string
+		update *md.Task
+		fields []string
+		want   string
+	}{
+		// правка заголовка не теряет просроченное повторение
+		{name: "title", repeat: "d 7", update: &md.Task{Title: "renamed"}, fields: []string{"title"}, want: "20240126"},
+		{name: "title once", update: &md.Task{Title: "renamed"}, fields: []string{"title"}, want: "20240126"},
+		// новая дата или правило переносятся на актуальную дату
+		{name: "date", repeat: "d 7", update: &md.Task{Date: "20240101"}, fields: []string{"date"}, want: "20240212"},
+		{name: "date once", update: &md.Task{Date: "20240101"}, fields: []string{"date"}, want: "20240210"},
+		{name: "repeat", update: &md.Task{Repeat: "d 7"}, fields: []string{"repeat"}, want: "20240216"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clock.Freeze(time.Date(2024, 1, 26, 12, 0, 0, 0, time.UTC))
+			id := addTestTask(t, s, &md.Task{Date: "20240126", Title: tc.name, Repeat: tc.repeat})
+			clock.Freeze(time.Date(2024, 2, 10, 12, 0, 0, 0, time.UTC))
+
+			tc.update.ID = id
+			if err := s.UpdateTask(ctx, tc.update, tc.fields); err != nil {
+				t.Fatalf("UpdateTask() error: %v", err)
+			}
+			task, err := s.GetTask(ctx, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if task.Date != tc.want {
+				t.Errorf("date = %q, want %q", task.Date, tc.want)
+			}
+		})
+	}
+}
+
+func TestUpdateDateTaskRemaining(t *testing.T) {
+	s, _ := testService(t)
+	ctx := context.Background()
+	id := addTestTask(t, s, &md.Task{Date: "20240130", Title: "series", Repeat: "d 7 count 5"})
+
+	two, zero, tooMany := 2, 0, 6
+	cases := []struct {
+		name      string
+		remaining *int
+		want      int // 0 — ожидается ErrInvalidParameter
+	}{
+		{name: "omitted", want: 5},
+		{name: "explicit", remaining: &two, want: 2},
+		{name: "unlimited", remaining: &zero},
+		{name: "above count", remaining: &tooMany},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := s.UpdateDateTask(ctx, "20240201", tc.remaining, id)
+			if tc.want == 0 {
+				if !errors.Is(err, apperrors.ErrInvalidParameter) {
+					t.Fatalf("UpdateDateTask() error = %v, want ErrInvalidParameter", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateDateTask() error: %v", err)
+			}
+			task, err := s.GetTask(ctx, id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if task.Remaining != tc.want {
+				t.Errorf("remaining = %d, want %d", task.Remaining, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckParent(t *testing.T) {
+	s, _ := testService(t)
+	ctx := context.Background()
+
+	// root → a → b → c: глубже c подзадач быть не может
+	root := addTestTask(t, s, &md.Task{Title: "root"})
+	a := addTestTask(t, s, &md.Task{Title: "a", ParentID: &root})
+	b := addTestTask(t, s, &md.Task{Title: "b", ParentID: &a})
+	c := addTestTask(t, s, &md.Task{Title: "c", ParentID: &b})
+	other := addTestTask(t, s, &md.Task{Title: "other"})
+	otherSub := addTestTask(t, s, &md.Task{Title: "other sub", ParentID: &other})
+	missing := 1000
+
+	cases := []struct {
+		name   string
+		id     int
+		parent int
+		want   error // nil — перенос допустим
+	}{
+		{name: "self", id: a, parent: a, want: apperrors.ErrSubtaskCycle},
+		{name: "into own subtree", id: a, parent: c, want: apperrors.ErrSubtaskCycle},
+		{name: "too deep", id: other, parent: c, want: apperrors.ErrSubtaskTooDeep},
+		// a переносится вместе с b и c
+		{name: "subtree too deep", id: a, parent: otherSub, want: apperrors.ErrSubtaskTooDeep},
+		{name: "subtree fits", id: a, parent: other},
+		{name: "missing parent", id: other, parent: missing, want: apperrors.ErrParentNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := s.UpdateTask(ctx, &md.Task{ID: tc.id, ParentID: &tc.parent}, []string{"parent_id"})
+			if tc.want == nil {
+				if err != nil {
+					t.Errorf("UpdateTask() error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.want) {
+				t.Errorf("UpdateTask() error = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+// TestCheckParentConcurrent два встречных переноса: по отдельности каждый допустим,
+// вместе они дали бы цикл. Блокировка цепочки родителей пропускает только один.
+func TestCheckParentConcurrent(t *testing.T) {
+	s, _ := testService(t)
+	ctx := context.Background()
+
+	for i := 0; i < 20; i++ {
+		a := addTestTask(t, s, &md.Task{Title: "a"})
+		b := addTestTask(t, s, &md.Task{Title: "b"})
+
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		for j, move := range [][2]int{{a, b}, {b, a}} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				parent := move[1]
+				errs[j] = s.UpdateTask(ctx, &md.Task{ID: move[0], ParentID: &parent}, []string{"parent_id"})
+			}()
+		}
+		wg.Wait()
+
+		if errs[0] == nil && errs[1] == nil {
+			t.Fatalf("both moves succeeded: %d and %d form a cycle", a, b)
+		}
+		taskA, err := s.GetTask(ctx, a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		taskB, err := s.GetTask(ctx, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if taskA.ParentID != nil && taskB.ParentID != nil {
+			t.Fatalf("cycle stored: %d → %d → %d", a, *taskA.ParentID, *taskB.ParentID)
+		}
+	}
+}
+
+func TestRevertTaskKeepsSeries(t *testing.T) {
+	s, _ := testService(t)
+	ctx := context.Background()
+	id := addTestTask(t, s, &md.Task{Date: "20240130", Title: "report", Repeat: "d 7 count 5"})
+
+	// версия 1 — до переименования, со счетчиком 5
+	if err := s.UpdateTask(ctx, &md.Task{ID: id, Title: "renamed"}, []string{"title"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.DoneTask(ctx, id, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SnoozeTask(ctx, id, 0, "20240208"); err != nil {
+		t.Fatal(err)
+	}
+
+	task, err := s.RevertTask(ctx, id, 1)
+	if err != nil {
+		t.Fatalf("RevertTask() error: %v", err)
+	}
+	if task.Title != "report" || task.Remaining != 4 || task.Anchor != "20240206" {
+		t.Errorf("after revert: title %q, remaining %d, anchor %q, want report, 4, 20240206",
+			task.Title, task.Remaining, task.Anchor)
+	}
+
+	// версия с другим правилом начинает счетчик с полного count
+	if err := s.UpdateTask(ctx, &md.Task{ID: id, Repeat: "d 7 count 3"}, []string{"repeat"}); err != nil {
+		t.Fatal(err)
+	}
+	revisions, err := s.ListRevisions(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err = s.RevertTask(ctx, id, revisions[len(revisions)-1].Number)
+	if err != nil {
+		t.Fatalf("RevertTask() error: %v", err)
+	}
+	if task.Repeat != "d 7 count 5" || task.Remaining != 5 {
+		t.Errorf("after rule revert: repeat %q, remaining %d, want d 7 count 5, 5", task.Repeat, task.Remaining)
+	}
+}
+
+func TestAuditRelatedChanges(t *testing.T) {
+	s, _ := testService(t)
+	ctx := context.Background()
+	store, err := blob.NewFSStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.blobs, s.maxAttachmentSize = store, 1<<20
+
+	project, err := s.CreateProject(ctx, &md.Project{Name: "work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := addTestTask(t, s, &md.Task{Date: "20240126", Title: "first", Tags: []string{"a", "b"}, ProjectID: &project.ID})
+	second := addTestTask(t, s, &md.Task{Date: "20240126", Title: "second"})
+
+	// events проверяет одно событие action по задаче и условие на состояние после
+	events := func(t *testing.T, id int, action string, check func(after *md.Task) bool) *md.AuditEvent {
+		t.Helper()
+		got, err := s.ListAuditEvents(ctx, md.AuditFilter{TaskID: id, Action: action})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || got[0].Before == nil || got[0].After == nil {
+			t.Fatalf("%s events = %+v, want one with before and after", action, got)
+		}
+		var after md.Task
+		if err := json.Unmarshal(got[0].After, &after); err != nil {
+			t.Fatal(err)
+		}
+		if !check(&after) {
+			t.Errorf("%s after = %+v", action, after)
+		}
+		return got[0]
+	}
+	tagID := func(name string) int {
+		tags, err := s.ListTags(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, tag := range tags {
+			if tag.Name == name {
+				return tag.ID
+			}
+		}
+		t.Fatalf("tag %q not found", name)
+		return 0
+	}
+
+	t.Run("dependency", func(t *testing.T) {
+		if _, err := s.AddDependency(ctx, first, second); err != nil {
+			t.Fatal(err)
+		}
+		// повторное добавление ничего не меняет и в журнал не попадает
+		if _, err := s.AddDependency(ctx, first, second); err != nil {
+			t.Fatal(err)
+		}
+		events(t, first, md.AuditAddDependency, func(after *md.Task) bool {
+			return slices.Equal(after.DependsOn, []int{second})
+		})
+		if _, err := s.RemoveDependency(ctx, first, second); err != nil {
+			t.Fatal(err)
+		}
+		events(t, first, md.AuditRemoveDependency, func(after *md.Task) bool {
+			return len(after.DependsOn) == 0
+		})
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		if _, err := s.RenameTag(ctx, tagID("a"), "c"); err != nil {
+			t.Fatal(err)
+		}
+		events(t, first, md.AuditRenameTag, func(after *md.Task) bool {
+			return slices.Contains(after.Tags, "c") && !slices.Contains(after.Tags, "a")
+		})
+		if _, err := s.MergeTags(ctx, []int{tagID("c")}, tagID("b")); err != nil {
+			t.Fatal(err)
+		}
+		events(t, first, md.AuditMergeTags, func(after *md.Task) bool {
+			return slices.Equal(after.Tags, []string{"b"})
+		})
+		if err := s.DeleteTag(ctx, tagID("b")); err != nil {
+			t.Fatal(err)
+		}
+		events(t, first, md.AuditDeleteTag, func(after *md.Task) bool {
+			return len(after.Tags) == 0
+		})
+	})
+
+	t.Run("project", func(t *testing.T) {
+		if err := s.DeleteProject(ctx, project.ID); err != nil {
+			t.Fatal(err)
+		}
+		events(t, first, md.AuditDeleteProject, func(after *md.Task) bool {
+			return after.ProjectID == nil
+		})
+	})
+
+	t.Run("attachment", func(t *testing.T) {
+		attachment, err := s.AddAttachment(ctx, &md.Attachment{TaskID: second, Name: "note.txt"}, strings.NewReader("hello"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.DeleteAttachment(ctx, attachment.ID); err != nil {
+			t.Fatal(err)
+		}
+		for _, action := range []string{md.AuditAddAttachment, md.AuditDeleteAttachment} {
+			e := events(t, second, action, func(after *md.Task) bool { return after.ID == second })
+			var detail md.Attachment
+			if err := json.Unmarshal(e.Detail, &detail); err != nil || detail.ID != attachment.ID || detail.Name != "note.txt" {
+				t.Errorf("%s detail = %s, %v", action, e.Detail, err)
+			}
+		}
+	})
+}
+
+func TestProjectStatsCancelled(t *testing.T) {
+	s, clock := testService(t)
+	ctx := context.Background()
+	// прошедшая дата при добавлении переносится на сегодня, поэтому часы переводятся позже
+	clock.Freeze(time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC))
+
+	project, err := s.CreateProject(ctx, &md.Project{Name: "work"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	addTestTask(t, s, &md.Task{Date: "20240120", Title: "overdue", ProjectID: &project.ID})
+	addTestTask(t, s, &md.Task{Date: "20240126", Title: "today", ProjectID: &project.ID})
+	// отмененные задачи в сроки, важность и повторения не входят
+	for _, task := range []*md.Task{
+		{Date: "20240120", Title: "cancelled overdue", Priority: md.PriorityHigh, ProjectID: &project.ID},
+		{Date: "20240126", Title: "cancelled today", Repeat: "d 7", ProjectID: &project.ID},
+		{Date: "20240201", Title: "cancelled upcoming", ProjectID: &project.ID},
+	} {
+		id := addTestTask(t, s, task)
+		if _, err := s.SetStatus(ctx, id, md.StatusCancelled); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	clock.Freeze(time.Date(2024, 1, 26, 12, 0, 0, 0, time.UTC))
+	stats, err := s.ProjectStats(ctx, project.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := md.ProjectStats{ProjectID: project.ID, Total: 5, Overdue: 1, DueToday: 1, Cancelled: 3}
+	if *stats != want {
+		t.Errorf("ProjectStats() = %+v, want %+v", *stats, want)
+	}
+}
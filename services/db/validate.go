@@ -0,0 +1,154 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	cm "github.com/Vasya-lis/firstWorkWithgRPC/common"
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	"github.com/Vasya-lis/firstWorkWithgRPC/common/validate"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+)
+
+// normalizeTask проверяет поля задачи и приводит дату к ближайшей актуальной:
+// пустая — сегодня, прошедшая (только при moveDate) — сегодня или следующее повторение
+// по правилу. Без moveDate просроченная дата остается: изменение других полей не должно
+// терять пропущенное повторение. Ошибки полей возвращаются вместе через errors.Join.
+func (s *TasksService) normalizeTask(task *md.Task, moveDate bool) error {
+	// метки приводим к хранимому виду до проверки: шаблон из proto не допускает пробелов
+	task.Tags = normalizeTags(task.Tags)
+
+	// сначала ограничения из proto, смысловые проверки поля после них не повторяем
+	var errs []error
+	invalid := make(map[string]bool)
+	for _, f := range apperrors.FieldErrors(validate.Message(taskToProto(task))) {
+		errs = append(errs, f)
+		invalid[f.Field] = true
+	}
+	addErr := func(field string, err error) {
+		if !invalid[field] {
+			invalid[field] = true
+			errs = append(errs, apperrors.NewFieldError(field, err))
+		}
+	}
+	fieldErr := func(field string, base, err error) {
+		addErr(field, fmt.Errorf("%w: %w", base, err))
+	}
+
+	// "сегодня" считаем в поясе задачи
+	now, err := cm.NowIn(s.clock.Now(), task.TZ)
+	if err != nil {
+		addErr("tz", apperrors.ErrInvalidTimeZone)
+		now = s.clock.Now()
+	}
+	if task.CatchUp, err = cm.CheckCatchUp(task.CatchUp); err != nil {
+		fieldErr("catch_up", apperrors.ErrInvalidCatchUp, err)
+	}
+
+	switch {
+	case task.Priority == md.PriorityUnspecified:
+		task.Priority = md.PriorityNormal
+	case !task.Priority.Valid():
+		addErr("priority", apperrors.ErrInvalidPriority)
+	}
+
+	// статус задается при создании, при обновлении задачи он не меняется
+	switch {
+	case task.Status == md.StatusUnspecified:
+		task.Status = md.StatusTodo
+	case !task.Status.Valid():
+		addErr("status", apperrors.ErrInvalidStatus)
+	}
+
+	if task.RepeatMode, err = cm.RepeatMode(task.Repeat, task.RepeatMode); err != nil {
+		fieldErr("repeat", apperrors.ErrInvalidRepeat, err)
+	}
+	repeatOK := !invalid["repeat"]
+	setRemaining(task)
+
+	if task.Date == "" {
+		task.Date = now.Format(cm.FormDate)
+	} else if _, err := time.Parse(cm.FormDate, task.Date); err != nil {
+		fieldErr("date", apperrors.ErrInvalidDateFormat, err)
+	}
+	dateOK := !invalid["date"]
+
+	// правило проверяем через NextDate и заодно получаем следующую дату,
+	// при неверной дате правило проверяется от сегодняшнего дня
+	var next string
+	if task.Repeat != "" && repeatOK {
+		base := task.Date
+		if !dateOK {
+			base = now.Format(cm.FormDate)
+		}
+		next, err = cm.NextDateExcept(now, base, task.Repeat, task.Exceptions)
+		if err != nil {
+			fieldErr("repeat", apperrors.ErrInvalidRepeat, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	// Если указанная дата раньше или равна сегодня (now), корректируем
+	date, _ := time.ParseInLocation(cm.FormDate, task.Date, now.Location())
+	if moveDate && cm.AfterNow(now, date) {
+		if task.Repeat == "" {
+			// без повтора — ставим сегодняшнюю дату
+			task.Date = now.Format(cm.FormDate)
+		} else {
+			// с повтором — ставим вычисленную следующую дату
+			task.Date = next
+		}
+	}
+	return nil
+}
+
+// setRemaining выставляет счетчик оставшихся повторений по модификатору count
+func setRemaining(task *md.Task) {
+	rule, err := cm.ParseRepeat(task.Repeat)
+	if err != nil || rule.Count == 0 {
+		task.Remaining = 0
+		return
+	}
+	// новый или неверный счетчик начинаем с полного count
+	if task.Remaining <= 0 || task.Remaining > rule.Count {
+		task.Remaining = rule.Count
+	}
+}
+
+// checkRemaining проверяет счетчик, заданный клиентом явно: у серии с count —
+// от 1 до count, без count — только 0
+func checkRemaining(repeat string, remaining int) error {
+	rule, err := cm.ParseRepeat(repeat)
+	max := 0
+	if err == nil {
+		max = rule.Count
+	}
+	if remaining < 0 || remaining > max || (max > 0 && remaining == 0) {
+		return apperrors.ErrInvalidParameter.With("name", "remaining")
+	}
+	return nil
+}
+
+// tagName приводит имя метки к хранимому виду: без пробелов по краям, в нижнем регистре
+func tagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// normalizeTags приводит имена меток к хранимому виду, убирает повторы и сортирует
+func normalizeTags(names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, tagName(name))
+	}
+	slices.Sort(tags)
+	return slices.Compact(tags)
+}
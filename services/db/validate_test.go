@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	cm "github.com/Vasya-lis/firstWorkWithgRPC/common"
+	apperrors "github.com/Vasya-lis/firstWorkWithgRPC/common/app_errors"
+	pb "github.com/Vasya-lis/firstWorkWithgRPC/proto"
+	md "github.com/Vasya-lis/firstWorkWithgRPC/services/models"
+	"google.golang.org/grpc"
+)
+
+func TestNormalizeTaskTags(t *testing.T) {
+	clock := &cm.FrozenClock{}
+	clock.Freeze(time.Date(2024, 1, 26, 12, 0, 0, 0, time.UTC))
+	s := &TasksService{clock: clock}
+
+	task := &md.Task{Title: "tags", Tags: []string{" Home ", "work", "HOME", "Infra\t"}}
+	if err := s.normalizeTask(task, true); err != nil {
+		t.Fatalf("normalizeTask() error: %v", err)
+	}
+	if want := []string{"home", "infra", "work"}; !reflect.DeepEqual(task.Tags, want) {
+		t.Errorf("tags = %v, want %v", task.Tags, want)
+	}
+
+	// пробел внутри имени остается ошибкой шаблона
+	task = &md.Task{Title: "tags", Tags: []string{"my home"}}
+	fields := apperrors.FieldErrors(s.normalizeTask(task, true))
+	if len(fields) != 1 || fields[0].Field != "tags[0]" {
+		t.Errorf("normalizeTask() field errors = %v, want tags[0]", fields)
+	}
+}
+
+func TestValidateUnaryNormalizesTags(t *testing.T) {
+	handler := func(ctx context.Context, req any) (any, error) { return req, nil }
+	info := &grpc.UnaryServerInfo{}
+
+	cases := []struct {
+		req   any
+		check func(req any) bool
+	}{
+		{&pb.Task{Title: "t", Tags: []string{" Home"}}, func(req any) bool {
+			return reflect.DeepEqual(req.(*pb.Task).Tags, []string{"home"})
+		}},
+		{&pb.UpdateTaskRequest{Task: &pb.Task{Id: 1, Title: "t", Tags: []string{"Work "}}}, func(req any) bool {
+			return reflect.DeepEqual(req.(*pb.UpdateTaskRequest).Task.Tags, []string{"work"})
+		}},
+		{&pb.CreateTagRequest{Name: " Home "}, func(req any) bool {
+			return req.(*pb.CreateTagRequest).Name == "home"
+		}},
+		{&pb.RenameTagRequest{Id: 1, Name: "Infra "}, func(req any) bool {
+			return req.(*pb.RenameTagRequest).Name == "infra"
+		}},
+	}
+	for _, tc := range cases {
+		got, err := validateUnary(context.Background(), tc.req, info, handler)
+		if err != nil {
+			t.Errorf("validateUnary(%v) error: %v", tc.req, err)
+			continue
+		}
+		if !tc.check(got) {
+			t.Errorf("validateUnary(%v): tags not normalized", got)
+		}
+	}
+}
+
+func TestValidateUnaryLegacyUpdate(t *testing.T) {
+	handler := func(ctx context.Context, req any) (any, error) { return req, nil }
+	info := &grpc.UnaryServerInfo{}
+
+	// без fields проверяются только поля старого API, остальные сервис не меняет
+	req := &pb.UpdateTaskRequest{Task: &pb.Task{Id: 1, Title: "t", Time: "25:00"}}
+	if _, err := validateUnary(context.Background(), req, info, handler); err != nil {
+		t.Errorf("validateUnary(legacy with unused time) error: %v", err)
+	}
+	req = &pb.UpdateTaskRequest{Task: &pb.Task{Id: 1, Title: "t", Date: "2024"}}
+	if _, err := validateUnary(context.Background(), req, info, handler); err == nil {
+		t.Error("validateUnary(legacy with bad date) error = nil")
+	}
+}
+
+func TestUpdateDateTaskInvalidDate(t *testing.T) {
+	s := &TasksService{clock: &cm.FrozenClock{}}
+
+	// дата проверяется до обращения к базе
+	for _, next := range []string{"20241399", "20240230", "2024-01-01", ""} {
+		fields := apperrors.FieldErrors(s.UpdateDateTask(context.Background(), next, nil, 1))
+		if len(fields) != 1 || fields[0].Field != "next_date" {
+			t.Errorf("UpdateDateTask(%q) field errors = %v, want next_date", next, fields)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Attachment файл задачи: метаданные хранятся в базе, содержимое — в хранилище
+// вложений по ключу Key. Записи удаляются вместе с задачей, содержимое удаляет
+// сервис после фиксации транзакции.
+type Attachment struct {
+	ID          int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	TaskID      int       `gorm:"not null;index" json:"task_id"`
+	Name        string    `gorm:"size:255;not null" json:"name"`
+	ContentType string    `gorm:"size:255;not null;default:''" json:"content_type"`
+	Size        int64     `gorm:"not null" json:"size"`                         // байт
+	SHA256      string    `gorm:"column:sha256;size:64;not null" json:"sha256"` // hex
+	Key         string    `gorm:"size:255;not null;uniqueIndex" json:"-"`       // ключ содержимого в хранилище
+	CreatedAt   time.Time `json:"created_at"`
+	Task        Task      `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// действия в журнале аудита
+const (
+	AuditAdd        = "add"
+	AuditUpdate     = "update"
+	AuditRevert     = "revert"
+	AuditDelete     = "delete"
+	AuditUpdateDate = "update_date"
+	AuditDone       = "done"
+	AuditSnooze     = "snooze"
+	AuditSkip       = "skip"
+	AuditSetStatus  = "set_status"
+	AuditCheckItem  = "check_item"
+	AuditReset      = "reset"    // подзадача начата заново со следующим повторением родителя
+	AuditCatchUp    = "catch_up" // фоновый перенос просроченной задачи на ближайшее повторение
+
+	AuditAddDependency    = "add_dependency"
+	AuditRemoveDependency = "remove_dependency"
+	AuditRenameTag        = "rename_tag"
+	AuditMergeTags        = "merge_tags"
+	AuditDeleteTag        = "delete_tag"
+	AuditDeleteProject    = "delete_project" // задача осталась без проекта
+	AuditAddAttachment    = "add_attachment"
+	AuditDeleteAttachment = "delete_attachment"
+)
+
+// AuditEvent запись журнала изменений задач. Записи только добавляются и
+// не связаны с задачей внешним ключом, поэтому переживают ее удаление.
+type AuditEvent struct {
+	ID         int        `gorm:"primaryKey;autoIncrement" json:"id"`
+	Action     string     `gorm:"size:32;not null;index" json:"action"`
+	TaskID     int        `gorm:"not null;index" json:"task_id"`
+	Actor      string     `gorm:"size:255;not null;default:''" json:"actor"`       // автор из X-Actor доверенного прокси
+	ClientAddr string     `gorm:"size:255;not null;default:''" json:"client_addr"` // адрес HTTP-клиента API
+	SourceAddr string     `gorm:"size:255;not null;default:''" json:"source_addr"` // адрес реплики API (peer gRPC)
+	RequestID  string     `gorm:"size:64;not null;default:'';index" json:"request_id"`
+	Before     AuditState `gorm:"type:jsonb" json:"before"`
+	After      AuditState `gorm:"type:jsonb" json:"after"`
+	Detail     AuditState `gorm:"type:jsonb" json:"detail"` // что изменилось вне задачи, например вложение
+	CreatedAt  time.Time  `gorm:"index" json:"created_at"`
+}
+
+// AuditState задача в JSON, как ее отдает API; nil — задачи нет (до создания или после удаления)
+type AuditState []byte
+
+// NewAuditState снимок задачи для журнала
+func NewAuditState(task *Task) (AuditState, error) {
+	if task == nil {
+		return nil, nil
+	}
+	return json.Marshal(task)
+}
+
+// NewAuditDetail подробности изменения в JSON, nil — подробностей нет
+func NewAuditDetail(detail any) (AuditState, error) {
+	if detail == nil {
+		return nil, nil
+	}
+	return json.Marshal(detail)
+}
+
+func (s AuditState) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return string(s), nil
+}
+
+func (s *AuditState) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*s = nil
+	case string:
+		*s = AuditState(v)
+	case []byte:
+		*s = slices.Clone(v)
+	default:
+		return fmt.Errorf("unsupported audit state type %T", src)
+	}
+	return nil
+}
+
+func (s AuditState) MarshalJSON() ([]byte, error) {
+	if s == nil {
+		return []byte("null"), nil
+	}
+	return s, nil
+}
+
+func (s *AuditState) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*s = nil
+		return nil
+	}
+	*s = slices.Clone(data)
+	return nil
+}
+
+// AuditFilter условия выборки журнала, записи идут по возрастанию id
+type AuditFilter struct {
+	TaskID    int       // 0 — все задачи
+	Action    string    // пустое — все действия
+	Actor     string    // пустое — все авторы
+	RequestID string    // пустое — все запросы
+	Since     time.Time // нулевое — без нижней границы
+	Until     time.Time // нулевое — без верхней границы, граница не включается
+	AfterID   int       // записи после id, для постраничного чтения
+	Limit     int
+}
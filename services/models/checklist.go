@@ -0,0 +1,73 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// ChecklistItem пункт чек-листа задачи
+type ChecklistItem struct {
+	Title string `json:"title"`
+	Done  bool   `json:"done"`
+}
+
+// Checklist чек-лист задачи, в базе хранится в jsonb
+type Checklist []ChecklistItem
+
+func (c Checklist) Value() (driver.Value, error) {
+	if c == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (c *Checklist) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("unsupported checklist type %T", src)
+	}
+	var items Checklist
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		items = nil
+	}
+	*c = items
+	return nil
+}
+
+// Reset возвращает копию чек-листа без отметок, для следующего повторения задачи
+func (c Checklist) Reset() Checklist {
+	if c == nil {
+		return nil
+	}
+	items := make(Checklist, len(c))
+	for i, item := range c {
+		items[i] = ChecklistItem{Title: item.Title}
+	}
+	return items
+}
+
+// HasDone проверяет, отмечен ли хотя бы один пункт
+func (c Checklist) HasDone() bool {
+	for _, item := range c {
+		if item.Done {
+			return true
+		}
+	}
+	return false
+}
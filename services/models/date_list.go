@@ -0,0 +1,43 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// DateList список дат YYYYMMDD, в базе хранится строкой через запятую
+type DateList []string
+
+func (l DateList) Value() (driver.Value, error) {
+	return strings.Join(l, ","), nil
+}
+
+func (l *DateList) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return fmt.Errorf("unsupported date list type %T", src)
+	}
+	if s == "" {
+		*l = nil
+		return nil
+	}
+	*l = strings.Split(s, ",")
+	return nil
+}
+
+// Contains проверяет наличие даты в списке
+func (l DateList) Contains(date string) bool {
+	for _, d := range l {
+		if d == date {
+			return true
+		}
+	}
+	return false
+}
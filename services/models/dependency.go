@@ -0,0 +1,17 @@
+package models
+
+// TaskDependency ребро графа зависимостей: задача TaskID ждет выполнения DependsOnID.
+// Ребра удаляются вместе с любой из задач.
+type TaskDependency struct {
+	TaskID      int  `gorm:"primaryKey"`
+	DependsOnID int  `gorm:"primaryKey;index"`
+	Task        Task `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	DependsOn   Task `gorm:"foreignKey:DependsOnID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// BlockedBy проверяет, блокирует ли предварительная задача prereq задачу t.
+// Разовая задача блокирует, пока не выполнена (выполненная удаляется), повторяющаяся —
+// пока ее ближайшее повторение не позже даты задачи t.
+func (t *Task) BlockedBy(prereq *Task) bool {
+	return prereq.Repeat == "" || prereq.Date <= t.Date
+}
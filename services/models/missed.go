@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// MissedOccurrence пропущенное повторение задачи в истории
+type MissedOccurrence struct {
+	ID        int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	TaskID    int       `gorm:"not null;index" json:"task_id"`
+	Date      string    `gorm:"size:8;not null;default:''" json:"date"`
+	Title     string    `gorm:"size:255;not null;default:''" json:"title"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Priority приоритет задачи, числа совпадают с enum Priority из proto.
+// В базе хранится числом, в JSON — названием (low, normal, high, urgent).
+type Priority int
+
+const (
+	PriorityUnspecified Priority = iota // не задан, при сохранении становится normal
+	PriorityLow
+	PriorityNormal
+	PriorityHigh
+	PriorityUrgent
+)
+
+var priorityNames = map[Priority]string{
+	PriorityLow:    "low",
+	PriorityNormal: "normal",
+	PriorityHigh:   "high",
+	PriorityUrgent: "urgent",
+}
+
+// ParsePriority разбирает название приоритета, пустое — не задан
+func ParsePriority(name string) (Priority, error) {
+	if name == "" {
+		return PriorityUnspecified, nil
+	}
+	for p, n := range priorityNames {
+		if n == name {
+			return p, nil
+		}
+	}
+	return PriorityUnspecified, fmt.Errorf("unknown priority %q", name)
+}
+
+// Valid проверяет, что приоритет задан и известен
+func (p Priority) Valid() bool {
+	_, ok := priorityNames[p]
+	return ok
+}
+
+func (p Priority) String() string {
+	if n, ok := priorityNames[p]; ok {
+		return n
+	}
+	if p == PriorityUnspecified {
+		return ""
+	}
+	return fmt.Sprintf("Priority(%d)", int(p))
+}
+
+func (p Priority) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+// UnmarshalJSON принимает название приоритета
+func (p *Priority) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("priority must be a string: %w", err)
+	}
+	parsed, err := ParsePriority(name)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Project список задач. Архивный проект доступен для чтения,
+// новые задачи в него не добавляются.
+type Project struct {
+	ID          int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name        string    `gorm:"size:255;not null;default:''" json:"name"`
+	Description string    `gorm:"not null;default:''" json:"description"`
+	Archived    bool      `gorm:"not null;default:false" json:"archived"`
+	CreatedAt   time.Time `json:"created_at"`
+	TaskCount   int       `gorm:"->;-:migration" json:"task_count"` // число задач проекта, только для чтения
+
+	// при удалении проекта задачи остаются без проекта
+	Tasks []Task `gorm:"foreignKey:ProjectID;constraint:OnDelete:SET NULL" json:"-"`
+}
+
+// ProjectStats сводка по задачам проекта, даты считаются по часам сервиса
+type ProjectStats struct {
+	ProjectID    int `json:"project_id"`
+	Total        int `json:"total"`
+	Overdue      int `json:"overdue"`
+	DueToday     int `json:"due_today"`
+	Upcoming     int `json:"upcoming"`
+	HighPriority int `json:"high_priority"` // high и urgent
+	Repeating    int `json:"repeating"`
+	Cancelled    int `json:"cancelled"` // в остальные счетчики, кроме total, не входят
+}
@@ -0,0 +1,163 @@
+package models
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TaskRevision прежняя версия задачи — снимок полей до изменения.
+// Номера идут по задаче с 1, версии удаляются вместе с задачей.
+type TaskRevision struct {
+	ID        int           `gorm:"primaryKey;autoIncrement" json:"id"`
+	TaskID    int           `gorm:"not null;uniqueIndex:idx_task_revisions_number" json:"task_id"`
+	Number    int           `gorm:"not null;uniqueIndex:idx_task_revisions_number" json:"number"`
+	Snapshot  TaskSnapshot  `gorm:"type:jsonb;not null" json:"snapshot"`
+	CreatedAt time.Time     `json:"created_at"`       // когда версию заменила следующая
+	Changes   []FieldChange `gorm:"-" json:"changes"` // чем следующая версия отличается от этой
+	Task      Task          `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// TaskSnapshot редактируемые поля задачи. Статус (у него своя история),
+// зависимости и служебные даты в версию не входят.
+type TaskSnapshot struct {
+	Date       string    `json:"date"`
+	Time       string    `json:"time"`
+	TZ         string    `json:"tz"`
+	Title      string    `json:"title"`
+	Comment    string    `json:"comment"`
+	Repeat     string    `json:"repeat"`
+	Remaining  int       `json:"remaining"`
+	RepeatMode string    `json:"repeat_mode"`
+	Anchor     string    `json:"anchor"`
+	Exceptions DateList  `json:"exceptions"`
+	CatchUp    string    `json:"catch_up"`
+	Priority   Priority  `json:"priority"`
+	Tags       []string  `json:"tags"`
+	ProjectID  *int      `json:"project_id"`
+	ParentID   *int      `json:"parent_id"`
+	Checklist  Checklist `json:"checklist"`
+}
+
+// Snapshot снимок редактируемых полей задачи
+func (t *Task) Snapshot() TaskSnapshot {
+	return TaskSnapshot{
+		Date:       t.Date,
+		Time:       t.Time,
+		TZ:         t.TZ,
+		Title:      t.Title,
+		Comment:    t.Comment,
+		Repeat:     t.Repeat,
+		Remaining:  t.Remaining,
+		RepeatMode: t.RepeatMode,
+		Anchor:     t.Anchor,
+		Exceptions: t.Exceptions,
+		CatchUp:    t.CatchUp,
+		Priority:   t.Priority,
+		Tags:       t.Tags,
+		ProjectID:  t.ProjectID,
+		ParentID:   t.ParentID,
+		Checklist:  t.Checklist,
+	}
+}
+
+// Apply переносит поля снимка в задачу, остальные поля задачи не меняются
+func (s TaskSnapshot) Apply(t *Task) {
+	t.Date = s.Date
+	t.Time = s.Time
+	t.TZ = s.TZ
+	t.Title = s.Title
+	t.Comment = s.Comment
+	t.Repeat = s.Repeat
+	t.Remaining = s.Remaining
+	t.RepeatMode = s.RepeatMode
+	t.Anchor = s.Anchor
+	t.Exceptions = s.Exceptions
+	t.CatchUp = s.CatchUp
+	t.Priority = s.Priority
+	t.Tags = s.Tags
+	t.ProjectID = s.ProjectID
+	t.ParentID = s.ParentID
+	t.Checklist = s.Checklist
+}
+
+// Merge переносит из from только поля с именами fields (как в JSON), остальные
+// остаются как в снимке. Имена вне снимка, например id или status, не учитываются.
+func (s TaskSnapshot) Merge(from TaskSnapshot, fields []string) TaskSnapshot {
+	dst, src := reflect.ValueOf(&s).Elem(), reflect.ValueOf(from)
+	for i := 0; i < dst.NumField(); i++ {
+		if slices.Contains(fields, fieldName(dst.Type().Field(i))) {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+	return s
+}
+
+func (s TaskSnapshot) Value() (driver.Value, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (s *TaskSnapshot) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("unsupported snapshot type %T", src)
+	}
+	return json.Unmarshal(data, s)
+}
+
+// FieldChange отличие поля между версиями, значения в JSON, как в API
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
+
+// Diff поля, которые в версии to отличаются от версии from, в порядке полей снимка.
+// Пустой список и его отсутствие не различаются.
+func Diff(from, to TaskSnapshot) ([]FieldChange, error) {
+	var changes []FieldChange
+	a, b := reflect.ValueOf(from), reflect.ValueOf(to)
+	for i := 0; i < a.NumField(); i++ {
+		oldVal, err := json.Marshal(a.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		newVal, err := json.Marshal(b.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(emptyList(oldVal), emptyList(newVal)) {
+			continue
+		}
+		changes = append(changes, FieldChange{Field: fieldName(a.Type().Field(i)), Old: oldVal, New: newVal})
+	}
+	return changes, nil
+}
+
+// fieldName имя поля снимка в JSON
+func fieldName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return name
+}
+
+// emptyList приводит отсутствующий список к пустому для сравнения
+func emptyList(data []byte) []byte {
+	if string(data) == "null" {
+		return []byte("[]")
+	}
+	return data
+}
@@ -0,0 +1,125 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	project := 3
+	otherProject := 4
+	base := TaskSnapshot{
+		Date:     "20240126",
+		Title:    "Отчет",
+		Repeat:   "d 7",
+		Priority: PriorityNormal,
+		Tags:     []string{"work"},
+	}
+
+	// change описывает ожидаемое отличие: поле и JSON старого и нового значения
+	type change struct{ field, old, new string }
+	cases := []struct {
+		name string
+		edit func(s *TaskSnapshot)
+		want []change
+	}{
+		{"no changes", func(s *TaskSnapshot) {}, nil},
+		{"title", func(s *TaskSnapshot) { s.Title = "Отчет за месяц" },
+			[]change{{"title", `"Отчет"`, `"Отчет за месяц"`}}},
+		{"fields in snapshot order", func(s *TaskSnapshot) {
+			s.Priority = PriorityHigh
+			s.Date = "20240202"
+		}, []change{{"date", `"20240126"`, `"20240202"`}, {"priority", `"normal"`, `"high"`}}},
+		{"tags", func(s *TaskSnapshot) { s.Tags = []string{"work", "home"} },
+			[]change{{"tags", `["work"]`, `["work","home"]`}}},
+		{"removed tags", func(s *TaskSnapshot) { s.Tags = nil },
+			[]change{{"tags", `["work"]`, `null`}}},
+		{"project set", func(s *TaskSnapshot) { s.ProjectID = &project },
+			[]change{{"project_id", `null`, `3`}}},
+		{"checklist", func(s *TaskSnapshot) { s.Checklist = Checklist{{Title: "a"}} },
+			[]change{{"checklist", `null`, `[{"title":"a","done":false}]`}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			to := base
+			to.Tags = append([]string(nil), base.Tags...)
+			tc.edit(&to)
+
+			changes, err := Diff(base, to)
+			if err != nil {
+				t.Fatalf("Diff() error: %v", err)
+			}
+			var got []change
+			for _, c := range changes {
+				got = append(got, change{c.Field, string(c.Old), string(c.New)})
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("Diff() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	// пустой список и его отсутствие не различаются
+	empty := base
+	empty.Tags = []string{}
+	noTags := base
+	noTags.Tags = nil
+	if changes, err := Diff(empty, noTags); err != nil || len(changes) != 0 {
+		t.Errorf("Diff(empty, nil tags) = %v, %v, want no changes", changes, err)
+	}
+
+	// одинаковые значения по разным указателям не считаются изменением
+	a, b := base, base
+	same := project
+	a.ProjectID, b.ProjectID = &project, &same
+	if changes, err := Diff(a, b); err != nil || len(changes) != 0 {
+		t.Errorf("Diff(same project) = %v, %v, want no changes", changes, err)
+	}
+	b.ProjectID = &otherProject
+	if changes, err := Diff(a, b); err != nil || len(changes) != 1 {
+		t.Errorf("Diff(other project) = %v, %v, want one change", changes, err)
+	}
+}
+
+func TestSnapshotApply(t *testing.T) {
+	project := 7
+	task := &Task{
+		ID: 5, Date: "20240126", Title: "Отчет", Repeat: "m 1", Remaining: 2,
+		Priority: PriorityHigh, Tags: []string{"work"}, ProjectID: &project, Status: StatusWaiting,
+	}
+	snapshot := task.Snapshot()
+
+	restored := &Task{ID: 5, Status: StatusTodo}
+	snapshot.Apply(restored)
+	if !reflect.DeepEqual(restored.Snapshot(), snapshot) {
+		t.Errorf("Apply() snapshot = %+v, want %+v", restored.Snapshot(), snapshot)
+	}
+	// статус в версию не входит
+	if restored.Status != StatusTodo {
+		t.Errorf("Apply() changed status to %v", restored.Status)
+	}
+}
+
+func TestSnapshotMerge(t *testing.T) {
+	project := 7
+	current := TaskSnapshot{
+		Date: "20240126", Title: "Отчет", Repeat: "m 1", Remaining: 2,
+		Priority: PriorityHigh, Tags: []string{"work"}, ProjectID: &project,
+	}
+	sent := TaskSnapshot{Title: "Отчет за месяц", Priority: PriorityLow}
+
+	got := current.Merge(sent, []string{"id", "title", "project_id", "status"})
+	want := current
+	want.Title = "Отчет за месяц"
+	want.ProjectID = nil
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Merge() = %+v, want %+v", got, want)
+	}
+	// исходный снимок не меняется
+	if current.Title != "Отчет" || current.ProjectID == nil {
+		t.Errorf("Merge() changed the receiver: %+v", current)
+	}
+	if got := current.Merge(sent, nil); !reflect.DeepEqual(got, current) {
+		t.Errorf("Merge() without fields = %+v, want %+v", got, current)
+	}
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Status статус задачи, числа совпадают с enum Status из proto.
+// В базе хранится числом, в JSON — названием (todo, in_progress, waiting, cancelled).
+// Выполнение статусом не является: DoneTask переносит или удаляет задачу.
+type Status int
+
+const (
+	StatusUnspecified Status = iota // не задан, при создании задачи становится todo
+	StatusTodo
+	StatusInProgress
+	StatusWaiting
+	StatusCancelled
+)
+
+var statusNames = map[Status]string{
+	StatusTodo:       "todo",
+	StatusInProgress: "in_progress",
+	StatusWaiting:    "waiting",
+	StatusCancelled:  "cancelled",
+}
+
+// допустимые переходы между статусами, отмененную задачу можно только вернуть в todo
+var statusTransitions = map[Status][]Status{
+	StatusTodo:       {StatusInProgress, StatusWaiting, StatusCancelled},
+	StatusInProgress: {StatusTodo, StatusWaiting, StatusCancelled},
+	StatusWaiting:    {StatusTodo, StatusInProgress, StatusCancelled},
+	StatusCancelled:  {StatusTodo},
+}
+
+// ParseStatus разбирает название статуса, пустое — не задан
+func ParseStatus(name string) (Status, error) {
+	if name == "" {
+		return StatusUnspecified, nil
+	}
+	for s, n := range statusNames {
+		if n == name {
+			return s, nil
+		}
+	}
+	return StatusUnspecified, fmt.Errorf("unknown status %q", name)
+}
+
+// Valid проверяет, что статус задан и известен
+func (s Status) Valid() bool {
+	_, ok := statusNames[s]
+	return ok
+}
+
+// CanTransition проверяет, можно ли сменить статус s на to
+func (s Status) CanTransition(to Status) bool {
+	return slices.Contains(statusTransitions[s], to)
+}
+
+func (s Status) String() string {
+	if n, ok := statusNames[s]; ok {
+		return n
+	}
+	if s == StatusUnspecified {
+		return ""
+	}
+	return fmt.Sprintf("Status(%d)", int(s))
+}
+
+func (s Status) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON принимает название статуса
+func (s *Status) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("status must be a string: %w", err)
+	}
+	parsed, err := ParseStatus(name)
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// StatusChange переход задачи между статусами в истории, удаляется вместе с задачей.
+// Первая запись — начальный статус созданной задачи, from у нее не задан.
+type StatusChange struct {
+	ID        int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	TaskID    int       `gorm:"not null;index" json:"task_id"`
+	From      Status    `gorm:"column:from_status;type:smallint;not null" json:"from"`
+	To        Status    `gorm:"column:to_status;type:smallint;not null" json:"to"`
+	ChangedAt time.Time `gorm:"not null" json:"changed_at"`
+	Task      Task      `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
@@ -0,0 +1,48 @@
+package models
+
+import "testing"
+
+func TestCanTransition(t *testing.T) {
+	all := []Status{StatusUnspecified, StatusTodo, StatusInProgress, StatusWaiting, StatusCancelled}
+	allowed := map[Status][]Status{
+		StatusTodo:       {StatusInProgress, StatusWaiting, StatusCancelled},
+		StatusInProgress: {StatusTodo, StatusWaiting, StatusCancelled},
+		StatusWaiting:    {StatusTodo, StatusInProgress, StatusCancelled},
+		StatusCancelled:  {StatusTodo},
+	}
+	for _, from := range all {
+		for _, to := range all {
+			want := false
+			for _, s := range allowed[from] {
+				if s == to {
+					want = true
+				}
+			}
+			if got := from.CanTransition(to); got != want {
+				t.Errorf("%v -> %v: CanTransition() = %v, want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	cases := []struct {
+		name    string
+		want    Status
+		wantErr bool
+	}{
+		{"", StatusUnspecified, false},
+		{"todo", StatusTodo, false},
+		{"in_progress", StatusInProgress, false},
+		{"waiting", StatusWaiting, false},
+		{"cancelled", StatusCancelled, false},
+		{"done", StatusUnspecified, true},
+		{"TODO", StatusUnspecified, true},
+	}
+	for _, tc := range cases {
+		got, err := ParseStatus(tc.name)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Errorf("ParseStatus(%q) = %v, %v; want %v, error %v", tc.name, got, err, tc.want, tc.wantErr)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+// Tag метка задачи, имя уникально и хранится в нижнем регистре
+type Tag struct {
+	ID        int    `gorm:"primaryKey;autoIncrement" json:"id"`
+	Name      string `gorm:"size:64;not null;uniqueIndex" json:"name"`
+	TaskCount int    `gorm:"->;-:migration" json:"task_count"` // число задач с меткой, только для чтения
+}
+
+// TaskTag связь задачи и метки, строки удаляются вместе с задачей или меткой
+type TaskTag struct {
+	TaskID int  `gorm:"primaryKey"`
+	TagID  int  `gorm:"primaryKey;index"`
+	Task   Task `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+	Tag    Tag  `gorm:"constraint:OnDelete:CASCADE" json:"-"`
+}
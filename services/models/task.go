@@ -1,9 +1,46 @@
 package models
 
+import (
+	"slices"
+	"time"
+)
+
 type Task struct {
-	ID      int    `gorm:"primaryKey;autoIncrement" json:"id"`
-	Date    string `gorm:"size:8;not null;default:''" json:"date"`
-	Title   string `gorm:"size:255;not null;default:''" json:"title"`
-	Comment string `gorm:"not null;default:''" json:"comment"`
-	Repeat  string `gorm:"size:128;not null;default:''" json:"repeat"`
+	ID         int       `gorm:"primaryKey;autoIncrement" json:"id"`
+	Date       string    `gorm:"size:8;not null;default:''" json:"date"`
+	Time       string    `gorm:"size:5;not null;default:''" json:"time"`
+	TZ         string    `gorm:"column:tz;size:64;not null;default:''" json:"tz"`
+	Title      string    `gorm:"size:255;not null;default:''" json:"title"`
+	Comment    string    `gorm:"not null;default:''" json:"comment"`
+	Repeat     string    `gorm:"size:128;not null;default:''" json:"repeat"`
+	Remaining  int       `gorm:"not null;default:0" json:"remaining"`
+	RepeatMode string    `gorm:"size:16;not null;default:'schedule'" json:"repeat_mode"`
+	Anchor     string    `gorm:"size:8;not null;default:''" json:"anchor"`
+	Exceptions DateList  `gorm:"type:text;not null;default:''" json:"exceptions"`
+	CatchUp    string    `gorm:"size:16;not null;default:'skip'" json:"catch_up"`
+	Priority   Priority  `gorm:"type:smallint;not null;default:2;index" json:"priority"`
+	Status     Status    `gorm:"type:smallint;not null;default:1;index" json:"status"`
+	Tags       []string  `gorm:"-" json:"tags"`           // имена меток, хранятся в task_tags
+	ProjectID  *int      `gorm:"index" json:"project_id"` // nil — задача без проекта
+	ParentID   *int      `gorm:"index" json:"parent_id"`  // nil — задача верхнего уровня
+	Checklist  Checklist `gorm:"type:jsonb;not null;default:'[]'" json:"checklist"`
+	DependsOn  []int     `gorm:"-" json:"depends_on"` // id задач, которые нужно выполнить раньше
+	Blocked    bool      `gorm:"-" json:"blocked"`    // есть невыполненные зависимости, считается при чтении
+
+	CreatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time `gorm:"not null;default:CURRENT_TIMESTAMP" json:"updated_at"` // любое изменение строки задачи
+
+	// подзадачи удаляются вместе с родителем
+	Children []Task `gorm:"foreignKey:ParentID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// Clone копия задачи, списки не разделяются с исходной
+func (t *Task) Clone() *Task {
+	c := *t
+	c.Exceptions = slices.Clone(t.Exceptions)
+	c.Tags = slices.Clone(t.Tags)
+	c.Checklist = slices.Clone(t.Checklist)
+	c.DependsOn = slices.Clone(t.DependsOn)
+	c.Children = nil
+	return &c
 }
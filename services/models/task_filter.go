@@ -0,0 +1,99 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// порядок списка задач
+const (
+	OrderDate     = "date"     // по дате и времени
+	OrderPriority = "priority" // сначала важные, внутри приоритета по дате и времени
+)
+
+// TaskFilter условия выборки списка задач. Порядок и фильтр одинаковы
+// для базы и кэша, поэтому список не зависит от того, откуда он прочитан.
+type TaskFilter struct {
+	Limit       int      // 0 или меньше — без ограничения
+	Search      string   // подстрока заголовка или комментария либо дата DD.MM.YYYY
+	MinPriority Priority // не ниже указанного, PriorityUnspecified — любые
+	Statuses    []Status // задачи в любом из статусов, пустой — все
+	Tags        []string // задачи со всеми указанными метками
+	ProjectID   int      // задачи проекта, 0 — все задачи
+	ParentID    int      // подзадачи задачи, 0 — все задачи
+	Order       string   // OrderDate или OrderPriority, пустой — по дате
+}
+
+// CheckOrder проверяет порядок списка, пустой — по дате
+func CheckOrder(order string) (string, error) {
+	switch order {
+	case "":
+		return OrderDate, nil
+	case OrderDate, OrderPriority:
+		return order, nil
+	default:
+		return "", fmt.Errorf("unknown order %q", order)
+	}
+}
+
+// MatchPriority проверяет фильтр по приоритету
+func (f TaskFilter) MatchPriority(task *Task) bool {
+	return task.Priority >= f.MinPriority
+}
+
+// MatchStatus проверяет фильтр по статусу
+func (f TaskFilter) MatchStatus(task *Task) bool {
+	return len(f.Statuses) == 0 || slices.Contains(f.Statuses, task.Status)
+}
+
+// MatchTags проверяет, что у задачи есть все метки фильтра
+func (f TaskFilter) MatchTags(task *Task) bool {
+	for _, tag := range f.Tags {
+		if !slices.Contains(task.Tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchProject проверяет фильтр по проекту
+func (f TaskFilter) MatchProject(task *Task) bool {
+	return f.ProjectID == 0 || task.ProjectID != nil && *task.ProjectID == f.ProjectID
+}
+
+// MatchParent проверяет фильтр по родительской задаче
+func (f TaskFilter) MatchParent(task *Task) bool {
+	return f.ParentID == 0 || task.ParentID != nil && *task.ParentID == f.ParentID
+}
+
+// Less сравнивает задачи в порядке фильтра, последним ключом идет ID
+func (f TaskFilter) Less(a, b *Task) bool {
+	if f.Order == OrderPriority && a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if a.Date != b.Date {
+		return a.Date < b.Date
+	}
+	if a.Time != b.Time {
+		return a.Time < b.Time
+	}
+	return a.ID < b.ID
+}
+
+// Sort упорядочивает задачи и обрезает список по лимиту
+func (f TaskFilter) Sort(tasks []*Task) []*Task {
+	sort.SliceStable(tasks, func(i, j int) bool { return f.Less(tasks[i], tasks[j]) })
+	if f.Limit > 0 && len(tasks) > f.Limit {
+		tasks = tasks[:f.Limit]
+	}
+	return tasks
+}
+
+// OrderSQL выражение ORDER BY, совпадающее с Less
+func (f TaskFilter) OrderSQL() string {
+	if f.Order == OrderPriority {
+		return "priority DESC, date ASC, time ASC, id ASC"
+	}
+	return "date ASC, time ASC, id ASC"
+}